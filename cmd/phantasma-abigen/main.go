@@ -0,0 +1,79 @@
+// Command phantasma-abigen generates typed Go bindings for a Phantasma contract.
+//
+// ABI is read either from a node:
+//
+//	phantasma-abigen -contract stake -rpc https://pharpc1.phantasma.info/rpc -pkg stake -out stake.go
+//
+// or from a file, JSON in getContract result format or binary .abi produced by the compiler:
+//
+//	//go:generate go run github.com/phantasma-io/phantasma-go/cmd/phantasma-abigen -file stake.json -pkg stake -out stake_binding.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/phantasma-io/phantasma-go/pkg/abigen"
+	"github.com/phantasma-io/phantasma-go/pkg/rpc"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+)
+
+func main() {
+	file := flag.String("file", "", "path to ABI file (.json or .abi)")
+	endpoint := flag.String("rpc", "", "RPC endpoint to read ABI from, used with -contract")
+	contractName := flag.String("contract", "", "contract name, used with -rpc or to override name from file")
+	chain := flag.String("chain", "main", "chain name, used with -rpc")
+	pkg := flag.String("pkg", "", "name of the generated package, defaults to contract name")
+	typeName := flag.String("type", "", "name of the binding type, defaults to contract name")
+	out := flag.String("out", "", "output file, defaults to stdout")
+	flag.Parse()
+
+	if err := run(*file, *endpoint, *contractName, *chain, *pkg, *typeName, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "phantasma-abigen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(file, endpoint, contractName, chain, pkg, typeName, out string) error {
+	var abi resp.ContractResult
+	var err error
+
+	switch {
+	case file != "" && endpoint != "":
+		return fmt.Errorf("only one of -file and -rpc can be used")
+	case file != "":
+		abi, err = abigen.LoadFile(file)
+		if err != nil {
+			return err
+		}
+		if contractName != "" {
+			abi.Name = contractName
+		}
+	case endpoint != "":
+		if contractName == "" {
+			return fmt.Errorf("-contract is required with -rpc")
+		}
+		abi, err = rpc.NewRPC(endpoint).GetContract(contractName, chain)
+		if err != nil {
+			return err
+		}
+		if abi.Name == "" {
+			abi.Name = contractName
+		}
+	default:
+		return fmt.Errorf("either -file or -rpc is required")
+	}
+
+	source, err := abigen.Generate(abi, abigen.Options{Package: pkg, TypeName: typeName})
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(source)
+		return err
+	}
+
+	return os.WriteFile(out, source, 0644)
+}
@@ -0,0 +1,4 @@
+// Package stake contains bindings for the stake contract generated by phantasma-abigen.
+package stake
+
+//go:generate go run ../../../cmd/phantasma-abigen -file stake.json -pkg stake -type Stake -out stake.go
@@ -0,0 +1,144 @@
+// Code generated by phantasma-abigen. DO NOT EDIT.
+
+package stake
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/abigen"
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+)
+
+// StakeContractName is the name of the contract on chain
+const StakeContractName = "stake"
+
+// Stake is a binding for stake contract.
+// Emit* methods append contract calls to a script, other methods invoke read-only calls.
+type Stake struct {
+	Invoker abigen.Invoker
+	Chain   string
+}
+
+// NewStake creates a binding which invokes read-only calls on the main chain
+func NewStake(invoker abigen.Invoker) *Stake {
+	return &Stake{Invoker: invoker, Chain: "main"}
+}
+
+// EmitStake appends stake.Stake() call to the script
+func (c *Stake) EmitStake(sb scriptbuilder.ScriptBuilder, from cryptography.Address, stakeAmount *big.Int) scriptbuilder.ScriptBuilder {
+	return sb.CallContract(StakeContractName, "Stake", from, stakeAmount)
+}
+
+// EmitUnstake appends stake.Unstake() call to the script
+func (c *Stake) EmitUnstake(sb scriptbuilder.ScriptBuilder, from cryptography.Address, unstakeAmount *big.Int) scriptbuilder.ScriptBuilder {
+	return sb.CallContract(StakeContractName, "Unstake", from, unstakeAmount)
+}
+
+// EmitGetStake appends stake.GetStake() call to the script
+func (c *Stake) EmitGetStake(sb scriptbuilder.ScriptBuilder, address cryptography.Address) scriptbuilder.ScriptBuilder {
+	return sb.CallContract(StakeContractName, "GetStake", address)
+}
+
+// GetStake invokes stake.GetStake() and decodes returned Number
+func (c *Stake) GetStake(address cryptography.Address) (*big.Int, error) {
+	script := c.EmitGetStake(scriptbuilder.BeginScript(), address).EndScript()
+
+	result, err := abigen.Invoke(c.Invoker, c.Chain, script)
+	if err != nil {
+		return nil, err
+	}
+
+	return abigen.DecodeNumber(result)
+}
+
+// EmitGetTimeBeforeUnstake appends stake.GetTimeBeforeUnstake() call to the script
+func (c *Stake) EmitGetTimeBeforeUnstake(sb scriptbuilder.ScriptBuilder, from cryptography.Address) scriptbuilder.ScriptBuilder {
+	return sb.CallContract(StakeContractName, "GetTimeBeforeUnstake", from)
+}
+
+// GetTimeBeforeUnstake invokes stake.GetTimeBeforeUnstake() and decodes returned Number
+func (c *Stake) GetTimeBeforeUnstake(from cryptography.Address) (*big.Int, error) {
+	script := c.EmitGetTimeBeforeUnstake(scriptbuilder.BeginScript(), from).EndScript()
+
+	result, err := abigen.Invoke(c.Invoker, c.Chain, script)
+	if err != nil {
+		return nil, err
+	}
+
+	return abigen.DecodeNumber(result)
+}
+
+// EmitGetStakeTimestamp appends stake.GetStakeTimestamp() call to the script
+func (c *Stake) EmitGetStakeTimestamp(sb scriptbuilder.ScriptBuilder, from cryptography.Address) scriptbuilder.ScriptBuilder {
+	return sb.CallContract(StakeContractName, "GetStakeTimestamp", from)
+}
+
+// GetStakeTimestamp invokes stake.GetStakeTimestamp() and decodes returned Timestamp
+func (c *Stake) GetStakeTimestamp(from cryptography.Address) (time.Time, error) {
+	script := c.EmitGetStakeTimestamp(scriptbuilder.BeginScript(), from).EndScript()
+
+	result, err := abigen.Invoke(c.Invoker, c.Chain, script)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return abigen.DecodeTime(result)
+}
+
+// EmitGetUnclaimed appends stake.GetUnclaimed() call to the script
+func (c *Stake) EmitGetUnclaimed(sb scriptbuilder.ScriptBuilder, from cryptography.Address) scriptbuilder.ScriptBuilder {
+	return sb.CallContract(StakeContractName, "GetUnclaimed", from)
+}
+
+// GetUnclaimed invokes stake.GetUnclaimed() and decodes returned Number
+func (c *Stake) GetUnclaimed(from cryptography.Address) (*big.Int, error) {
+	script := c.EmitGetUnclaimed(scriptbuilder.BeginScript(), from).EndScript()
+
+	result, err := abigen.Invoke(c.Invoker, c.Chain, script)
+	if err != nil {
+		return nil, err
+	}
+
+	return abigen.DecodeNumber(result)
+}
+
+// EmitClaim appends stake.Claim() call to the script
+func (c *Stake) EmitClaim(sb scriptbuilder.ScriptBuilder, from cryptography.Address, stakeAddress cryptography.Address) scriptbuilder.ScriptBuilder {
+	return sb.CallContract(StakeContractName, "Claim", from, stakeAddress)
+}
+
+// EmitGetMasterCount appends stake.GetMasterCount() call to the script
+func (c *Stake) EmitGetMasterCount(sb scriptbuilder.ScriptBuilder) scriptbuilder.ScriptBuilder {
+	return sb.CallContract(StakeContractName, "GetMasterCount")
+}
+
+// GetMasterCount invokes stake.GetMasterCount() and decodes returned Number
+func (c *Stake) GetMasterCount() (*big.Int, error) {
+	script := c.EmitGetMasterCount(scriptbuilder.BeginScript()).EndScript()
+
+	result, err := abigen.Invoke(c.Invoker, c.Chain, script)
+	if err != nil {
+		return nil, err
+	}
+
+	return abigen.DecodeNumber(result)
+}
+
+// EmitIsMaster appends stake.IsMaster() call to the script
+func (c *Stake) EmitIsMaster(sb scriptbuilder.ScriptBuilder, address cryptography.Address) scriptbuilder.ScriptBuilder {
+	return sb.CallContract(StakeContractName, "IsMaster", address)
+}
+
+// IsMaster invokes stake.IsMaster() and decodes returned Bool
+func (c *Stake) IsMaster(address cryptography.Address) (bool, error) {
+	script := c.EmitIsMaster(scriptbuilder.BeginScript(), address).EndScript()
+
+	result, err := abigen.Invoke(c.Invoker, c.Chain, script)
+	if err != nil {
+		return false, err
+	}
+
+	return abigen.DecodeBool(result)
+}
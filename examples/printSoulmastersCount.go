@@ -1,9 +1,13 @@
 package main
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
+	"math/big"
+	"time"
 
+	"github.com/phantasma-io/phantasma-go/pkg/rpc"
 	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
 )
 
@@ -32,28 +36,20 @@ func printSoulmastersCount() {
 }
 
 func printSoulmastersCountAndLastInflationDate() {
-	// Build script
-	sb := scriptbuilder.BeginScript().
-		CallContract("stake", "GetMasterCount")
-
-	sb.CallContract("gas", "GetLastInflationDate")
-
-	script := sb.EndScript()
-
-	// Before sending script to the chain we need to encode it into Base16 encoding (HEX)
-	encodedScript := hex.EncodeToString(script)
-	fmt.Println("Script: " + encodedScript)
-
 	if !PromptYNChoice("Invoke script?") {
 		return
 	}
 
-	// Make the call itself
-	result, err := client.InvokeRawScript("main", encodedScript)
+	// Both values are read with a single script invocation
+	var mastersCount *big.Int
+	var lastInflationDate time.Time
+	_, err := client.MultiCall(context.Background(), "main",
+		rpc.NewContractCall("stake", "GetMasterCount").Into(&mastersCount),
+		rpc.NewContractCall("gas", "GetLastInflationDate").Into(&lastInflationDate))
 
 	if err != nil {
 		panic("Script invocation failed! Error: " + err.Error())
 	}
 
-	fmt.Printf("Current SoulMasters count: %s, last inflation date: %s \n", result.DecodeResults(0).AsString(), result.DecodeResults(1).AsString())
+	fmt.Printf("Current SoulMasters count: %s, last inflation date: %s \n", mastersCount.String(), lastInflationDate.String())
 }
@@ -0,0 +1,370 @@
+// Package abigen generates typed Go bindings for Phantasma contracts from their ABI.
+//
+// ABI can be taken from a node (getContract), from a JSON file with the same structure
+// as getContract result, or from a binary .abi file produced by the contract compiler.
+// Generated bindings build scripts with scriptbuilder, invoke read-only methods
+// via InvokeRawScript and decode returned values into Go types.
+package abigen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/phantasma-io/phantasma-go/pkg/domain/contract"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+)
+
+// Options configure generated code
+type Options struct {
+	// Package is the name of the generated package
+	Package string
+	// TypeName is the name of the binding type, by default derived from contract name
+	TypeName string
+}
+
+// LoadFile reads contract ABI from a file. Files with .abi extension are treated
+// as binary contract interfaces, name of the file is used as contract name.
+// Other files are parsed as JSON in the getContract result format.
+func LoadFile(path string) (resp.ContractResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return resp.ContractResult{}, err
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".abi") {
+		br := io.NewBinReaderFromBuf(data)
+		var abi contract.ContractInterface
+		abi.Deserialize(br)
+		if br.Err != nil {
+			return resp.ContractResult{}, fmt.Errorf("cannot read abi %s: %w", path, br.Err)
+		}
+
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		return FromInterface(name, &abi), nil
+	}
+
+	var result resp.ContractResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return resp.ContractResult{}, fmt.Errorf("cannot parse abi %s: %w", path, err)
+	}
+
+	return result, nil
+}
+
+// FromInterface converts contract interface into the same structure node returns from getContract
+func FromInterface(name string, abi *contract.ContractInterface) resp.ContractResult {
+	result := resp.ContractResult{
+		Name:    name,
+		Methods: []resp.ABIMethodResult{},
+		Events:  []resp.ABIEventResult{},
+	}
+
+	if abi.Methods != nil {
+		for pair := abi.Methods.Oldest(); pair != nil; pair = pair.Next() {
+			method := resp.ABIMethodResult{
+				Name:       pair.Value.Name,
+				ReturnType: pair.Value.ReturnType.String(),
+				Parameters: []resp.ABIParameterResult{},
+			}
+			for _, p := range pair.Value.Parameters {
+				method.Parameters = append(method.Parameters, resp.ABIParameterResult{Name: p.Name, Type: p.Type.String()})
+			}
+			result.Methods = append(result.Methods, method)
+		}
+	}
+
+	for _, e := range abi.Events {
+		result.Events = append(result.Events, resp.ABIEventResult{
+			Value:       int(e.Value),
+			Name:        e.Name,
+			ReturnType:  e.ReturnType.String(),
+			Description: string(e.Description),
+		})
+	}
+
+	return result
+}
+
+// goType describes how values of a vm type are represented in generated code
+type goType struct {
+	// Param is the type of method parameters
+	Param string
+	// Result is the type of decoded return values
+	Result string
+	// Zero is the zero value of Result type
+	Zero string
+	// Decoder is the name of abigen function decoding vm object into Result type
+	Decoder string
+	// Arg is appended to parameter name when it's passed to script builder, empty if passed as is
+	Arg string
+	// ParamImports and ResultImports are packages required by Param and Result types
+	ParamImports  []string
+	ResultImports []string
+}
+
+const (
+	bigImport    = `"math/big"`
+	timeImport   = `"time"`
+	cryptoImport = `"github.com/phantasma-io/phantasma-go/pkg/cryptography"`
+	vmImport     = `"github.com/phantasma-io/phantasma-go/pkg/vm"`
+)
+
+var goTypes = map[vm.VMType]goType{
+	vm.Number:    {"*big.Int", "*big.Int", "nil", "DecodeNumber", "", []string{bigImport}, []string{bigImport}},
+	vm.String:    {"string", "string", `""`, "DecodeString", "", nil, nil},
+	vm.Bool:      {"bool", "bool", "false", "DecodeBool", "", nil, nil},
+	vm.Bytes:     {"[]byte", "[]byte", "nil", "DecodeBytes", "", nil, nil},
+	vm.Timestamp: {"time.Time", "time.Time", "time.Time{}", "DecodeTime", "", []string{timeImport}, []string{timeImport}},
+	vm.Enum:      {"abigen.Enum", "abigen.Enum", "0", "DecodeEnum", ".VMObject()", nil, nil},
+	vm.Object:    {"cryptography.Address", "cryptography.Address", "cryptography.Address{}", "DecodeAddress", "", []string{cryptoImport}, []string{cryptoImport}},
+	vm.Struct:    {"*vm.VMObject", "*vm.VMObject", "nil", "DecodeObject", "", []string{vmImport}, []string{vmImport}},
+}
+
+type paramData struct {
+	Name string
+	Type string
+}
+
+type methodData struct {
+	Name     string
+	GoName   string
+	Params   []paramData
+	Names    string
+	Args     string
+	HasCall  bool
+	Result   goType
+	ReturnVM string
+}
+
+type eventData struct {
+	GoName      string
+	Name        string
+	Value       int
+	Description string
+}
+
+type templateData struct {
+	Package  string
+	TypeName string
+	Contract string
+	StdLib   []string
+	Imports  []string
+	Methods  []methodData
+	Events   []eventData
+}
+
+var bindingTemplate = template.Must(template.New("binding").Parse(`// Code generated by phantasma-abigen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .StdLib}}
+	{{.}}
+{{- end}}
+{{if .StdLib}}
+{{end}}
+{{- range .Imports}}
+	{{.}}
+{{- end}}
+)
+
+// {{.TypeName}}ContractName is the name of the contract on chain
+const {{.TypeName}}ContractName = "{{.Contract}}"
+{{if .Events}}
+// Events of {{.Contract}} contract
+const (
+{{- range .Events}}
+	{{.GoName}} event.EventKind = {{.Value}}{{if .Description}} // {{.Description}}{{end}}
+{{- end}}
+)
+{{end}}
+// {{.TypeName}} is a binding for {{.Contract}} contract.
+// Emit* methods append contract calls to a script, other methods invoke read-only calls.
+type {{.TypeName}} struct {
+	Invoker abigen.Invoker
+	Chain   string
+}
+
+// New{{.TypeName}} creates a binding which invokes read-only calls on the main chain
+func New{{.TypeName}}(invoker abigen.Invoker) *{{.TypeName}} {
+	return &{{.TypeName}}{Invoker: invoker, Chain: "main"}
+}
+{{range $m := .Methods}}
+// Emit{{.GoName}} appends {{$.Contract}}.{{.Name}}() call to the script
+func (c *{{$.TypeName}}) Emit{{.GoName}}(sb scriptbuilder.ScriptBuilder{{range .Params}}, {{.Name}} {{.Type}}{{end}}) scriptbuilder.ScriptBuilder {
+	return sb.CallContract({{$.TypeName}}ContractName, "{{.Name}}"{{.Args}})
+}
+{{if .HasCall}}
+// {{.GoName}} invokes {{$.Contract}}.{{.Name}}() and decodes returned {{.ReturnVM}}
+func (c *{{$.TypeName}}) {{.GoName}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{$p.Name}} {{$p.Type}}{{end}}) ({{.Result.Result}}, error) {
+	script := c.Emit{{.GoName}}(scriptbuilder.BeginScript(){{.Names}}).EndScript()
+
+	result, err := abigen.Invoke(c.Invoker, c.Chain, script)
+	if err != nil {
+		return {{.Result.Zero}}, err
+	}
+
+	return abigen.{{.Result.Decoder}}(result)
+}
+{{end}}{{end}}`))
+
+// Generate returns formatted Go source of the contract binding
+func Generate(c resp.ContractResult, opts Options) ([]byte, error) {
+	if c.Name == "" {
+		return nil, fmt.Errorf("contract name is empty")
+	}
+
+	data := templateData{
+		Package:  opts.Package,
+		TypeName: opts.TypeName,
+		Contract: c.Name,
+	}
+
+	if data.Package == "" {
+		data.Package = strings.ToLower(identifier(c.Name, false))
+	}
+	if data.TypeName == "" {
+		data.TypeName = identifier(c.Name, true)
+	}
+
+	imports := map[string]bool{
+		`"github.com/phantasma-io/phantasma-go/pkg/abigen"`:                          true,
+		`scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"`: true,
+	}
+
+	seen := make(map[string]bool)
+	for _, m := range c.Methods {
+		goName := identifier(m.Name, true)
+		if seen[goName] {
+			return nil, fmt.Errorf("method %s is defined more than once", m.Name)
+		}
+		seen[goName] = true
+
+		method := methodData{Name: m.Name, GoName: goName, ReturnVM: m.ReturnType}
+
+		used := map[string]bool{"c": true, "sb": true, "script": true, "result": true, "err": true}
+		for _, p := range m.Parameters {
+			t, err := vm.ParseVMType(p.Type)
+			if err != nil {
+				return nil, fmt.Errorf("method %s parameter %s: %w", m.Name, p.Name, err)
+			}
+
+			gt, ok := goTypes[t]
+			if !ok {
+				return nil, fmt.Errorf("method %s parameter %s has unsupported type %s", m.Name, p.Name, p.Type)
+			}
+			for _, i := range gt.ParamImports {
+				imports[i] = true
+			}
+
+			name := identifier(p.Name, false)
+			for used[name] || isKeyword(name) {
+				name += "_"
+			}
+			used[name] = true
+
+			method.Params = append(method.Params, paramData{Name: name, Type: gt.Param})
+			method.Names += ", " + name
+			method.Args += ", " + name + gt.Arg
+		}
+
+		returnType, err := vm.ParseVMType(m.ReturnType)
+		if err != nil {
+			return nil, fmt.Errorf("method %s return type: %w", m.Name, err)
+		}
+
+		if returnType != vm.None {
+			method.HasCall = true
+			method.Result = goTypes[returnType]
+			for _, i := range method.Result.ResultImports {
+				imports[i] = true
+			}
+		}
+
+		data.Methods = append(data.Methods, method)
+	}
+
+	for _, e := range c.Events {
+		imports[`"github.com/phantasma-io/phantasma-go/pkg/domain/event"`] = true
+		data.Events = append(data.Events, eventData{
+			GoName:      data.TypeName + "Event" + identifier(e.Name, true),
+			Name:        e.Name,
+			Value:       e.Value,
+			Description: strings.Join(strings.Fields(e.Description), " "),
+		})
+	}
+
+	for i := range imports {
+		if strings.Contains(i, ".") {
+			data.Imports = append(data.Imports, i)
+		} else {
+			data.StdLib = append(data.StdLib, i)
+		}
+	}
+	slices.Sort(data.StdLib)
+	slices.Sort(data.Imports)
+
+	var buf bytes.Buffer
+	if err := bindingTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated code is invalid: %w", err)
+	}
+
+	return source, nil
+}
+
+// identifier converts a name to a valid Go identifier
+func identifier(name string, exported bool) string {
+	var sb strings.Builder
+	upperNext := exported
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upperNext = sb.Len() > 0 || exported
+			continue
+		}
+
+		if sb.Len() == 0 && unicode.IsDigit(r) {
+			sb.WriteRune('_')
+		}
+
+		if upperNext {
+			r = unicode.ToUpper(r)
+			upperNext = false
+		} else if sb.Len() == 0 {
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+
+	if sb.Len() == 0 {
+		return "_"
+	}
+
+	return sb.String()
+}
+
+func isKeyword(s string) bool {
+	switch s {
+	case "break", "case", "chan", "const", "continue", "default", "defer", "else",
+		"fallthrough", "for", "func", "go", "goto", "if", "import", "interface",
+		"map", "package", "range", "return", "select", "struct", "switch", "type", "var",
+		"abigen", "scriptbuilder", "big", "time", "cryptography", "vm", "event":
+		return true
+	}
+
+	return false
+}
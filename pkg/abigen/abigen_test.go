@@ -0,0 +1,170 @@
+package abigen_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"go/parser"
+	"go/token"
+	"math/big"
+	"testing"
+
+	"github.com/phantasma-io/phantasma-go/pkg/abigen"
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testABI = resp.ContractResult{
+	Name: "my-token",
+	Methods: []resp.ABIMethodResult{
+		{Name: "transfer", ReturnType: "None", Parameters: []resp.ABIParameterResult{
+			{Name: "from", Type: "Object"}, {Name: "to", Type: "Object"}, {Name: "amount", Type: "Number"},
+		}},
+		{Name: "getName", ReturnType: "String", Parameters: []resp.ABIParameterResult{}},
+		{Name: "getOwner", ReturnType: "Object", Parameters: []resp.ABIParameterResult{
+			{Name: "type", Type: "String"}, {Name: "sb", Type: "Bytes"},
+		}},
+		{Name: "getCreated", ReturnType: "Timestamp", Parameters: []resp.ABIParameterResult{
+			{Name: "flag", Type: "Bool"}, {Name: "kind", Type: "Enum"},
+		}},
+		{Name: "getInfo", ReturnType: "Struct", Parameters: []resp.ABIParameterResult{}},
+	},
+	Events: []resp.ABIEventResult{
+		{Value: 100, Name: "Minted", ReturnType: "Number", Description: "tokens\nminted"},
+	},
+}
+
+func TestGenerate(t *testing.T) {
+	source, err := abigen.Generate(testABI, abigen.Options{})
+	require.Nil(t, err)
+
+	file, err := parser.ParseFile(token.NewFileSet(), "binding.go", source, 0)
+	require.Nil(t, err, string(source))
+	assert.Equal(t, "mytoken", file.Name.Name)
+
+	code := string(source)
+	assert.Contains(t, code, `const MyTokenContractName = "my-token"`)
+	assert.Contains(t, code, "MyTokenEventMinted event.EventKind = 100 // tokens minted")
+	assert.Contains(t, code, "func (c *MyToken) EmitTransfer(sb scriptbuilder.ScriptBuilder, from cryptography.Address, to cryptography.Address, amount *big.Int) scriptbuilder.ScriptBuilder")
+	assert.NotContains(t, code, "func (c *MyToken) Transfer(")
+	assert.Contains(t, code, "func (c *MyToken) GetName() (string, error)")
+	assert.Contains(t, code, "func (c *MyToken) GetOwner(type_ string, sb_ []byte) (cryptography.Address, error)")
+	assert.Contains(t, code, "func (c *MyToken) GetCreated(flag bool, kind abigen.Enum) (time.Time, error)")
+	assert.Contains(t, code, "func (c *MyToken) GetInfo() (*vm.VMObject, error)")
+
+	source, err = abigen.Generate(testABI, abigen.Options{Package: "bindings", TypeName: "Token"})
+	require.Nil(t, err)
+	assert.Contains(t, string(source), "package bindings")
+	assert.Contains(t, string(source), "func NewToken(invoker abigen.Invoker) *Token")
+}
+
+func TestGenerateErrors(t *testing.T) {
+	_, err := abigen.Generate(resp.ContractResult{}, abigen.Options{})
+	assert.NotNil(t, err)
+
+	_, err = abigen.Generate(resp.ContractResult{Name: "c", Methods: []resp.ABIMethodResult{
+		{Name: "m", ReturnType: "Unknown"},
+	}}, abigen.Options{})
+	assert.NotNil(t, err)
+
+	_, err = abigen.Generate(resp.ContractResult{Name: "c", Methods: []resp.ABIMethodResult{
+		{Name: "m", ReturnType: "None"}, {Name: "M", ReturnType: "None"},
+	}}, abigen.Options{})
+	assert.NotNil(t, err)
+}
+
+type fakeInvoker struct {
+	script string
+	result *vm.VMObject
+}
+
+func (f *fakeInvoker) InvokeRawScript(chain, script string) (resp.ScriptResult, error) {
+	f.script = script
+	if f.result == nil {
+		return resp.ScriptResult{}, nil
+	}
+	return resp.ScriptResult{Result: hex.EncodeToString(io.Serialize(f.result))}, nil
+}
+
+func TestInvoke(t *testing.T) {
+	invoker := &fakeInvoker{result: vm.NewString("12345")}
+	script := scriptbuilder.BeginScript().CallContract("token", "getName").EndScript()
+
+	result, err := abigen.Invoke(invoker, "main", script)
+	require.Nil(t, err)
+	assert.Equal(t, hex.EncodeToString(script), invoker.script)
+
+	s, err := abigen.DecodeString(result)
+	require.Nil(t, err)
+	assert.Equal(t, "12345", s)
+
+	n, err := abigen.DecodeNumber(result)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(12345), n)
+
+	_, err = abigen.DecodeBool(result)
+	assert.NotNil(t, err)
+
+	invoker.result = nil
+	_, err = abigen.Invoke(invoker, "main", script)
+	assert.True(t, errors.Is(err, abigen.ErrNoResult))
+}
+
+type truncatedInvoker struct{}
+
+func (truncatedInvoker) InvokeRawScript(chain, script string) (resp.ScriptResult, error) {
+	// string of 5 bytes with only 2 bytes present
+	return resp.ScriptResult{Result: hex.EncodeToString([]byte{byte(vm.String), 5, 'a', 'b'})}, nil
+}
+
+func TestInvokeTruncatedResult(t *testing.T) {
+	_, err := abigen.Invoke(truncatedInvoker{}, "main", []byte{byte(vm.RET)})
+	assert.NotNil(t, err)
+}
+
+func TestEnum(t *testing.T) {
+	script := scriptbuilder.BeginScript().CallInterop("Runtime.Kind", abigen.Enum(3).VMObject()).EndScript()
+
+	machine := vm.NewVirtualMachine(script)
+	machine.RegisterInterop("Runtime.Kind", func(m *vm.VirtualMachine) error {
+		obj, err := m.Pop()
+		if err != nil {
+			return err
+		}
+		m.Push(obj)
+		return nil
+	})
+	_, err := machine.Execute()
+	require.Nil(t, err)
+
+	stack := machine.Stack()
+	require.Len(t, stack, 1)
+	assert.Equal(t, vm.Enum, stack[0].Type)
+
+	kind, err := abigen.DecodeEnum(&stack[0])
+	require.Nil(t, err)
+	assert.Equal(t, abigen.Enum(3), kind)
+}
+
+func TestDecodeAddress(t *testing.T) {
+	address := cryptography.NewPhantasmaKeys(make([]byte, 32)).Address()
+
+	decoded, err := abigen.DecodeAddress(vm.NewAddress(address))
+	require.Nil(t, err)
+	assert.Equal(t, address, decoded)
+
+	decoded, err = abigen.DecodeAddress(vm.NewString(address.String()))
+	require.Nil(t, err)
+	assert.Equal(t, address, decoded)
+
+	decoded, err = abigen.DecodeAddress(vm.NewBytes(address.BytesPrefixed()))
+	require.Nil(t, err)
+	assert.Equal(t, address, decoded)
+
+	_, err = abigen.DecodeAddress(vm.NewBool(true))
+	assert.NotNil(t, err)
+}
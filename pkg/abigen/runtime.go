@@ -0,0 +1,144 @@
+package abigen
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/types"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+)
+
+// Invoker executes read-only scripts, it's implemented by rpc.PhantasmaRPC
+type Invoker interface {
+	InvokeRawScript(chain, script string) (resp.ScriptResult, error)
+}
+
+// ErrNoResult is returned when invoked script didn't return any value
+var ErrNoResult = errors.New("script returned no result")
+
+// Invoke executes script and returns the first returned value
+func Invoke(invoker Invoker, chain string, script []byte) (*vm.VMObject, error) {
+	if invoker == nil {
+		return nil, errors.New("invoker is not set")
+	}
+
+	result, err := invoker.InvokeRawScript(chain, hex.EncodeToString(script))
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Result == "" {
+		return nil, ErrNoResult
+	}
+
+	data, err := hex.DecodeString(result.Result)
+	if err != nil {
+		return nil, fmt.Errorf("invalid script result: %w", err)
+	}
+
+	obj := &vm.VMObject{}
+	br := io.NewBinReaderFromBuf(data)
+	obj.Deserialize(br)
+	if br.Err != nil {
+		return nil, fmt.Errorf("invalid script result: %w", br.Err)
+	}
+
+	return obj, nil
+}
+
+// Enum is a value of VM Enum type. Generated bindings take and return it instead of a plain
+// integer, which script builder would load as a Number.
+type Enum uint32
+
+// VMObject returns enum as a vm object, so it's loaded into script as an Enum
+func (e Enum) VMObject() *vm.VMObject {
+	return vm.NewEnum(uint32(e))
+}
+
+func unexpectedType(obj *vm.VMObject, expected string) error {
+	return fmt.Errorf("cannot decode %s as %s", obj.Type, expected)
+}
+
+// DecodeNumber decodes returned value as a number
+func DecodeNumber(obj *vm.VMObject) (*big.Int, error) {
+	switch obj.Type {
+	case vm.Number, vm.Enum, vm.Bool, vm.Timestamp:
+		return obj.AsNumber(), nil
+	case vm.String:
+		if n, ok := new(big.Int).SetString(obj.Data.(string), 10); ok {
+			return n, nil
+		}
+	}
+
+	return nil, unexpectedType(obj, "number")
+}
+
+// DecodeString decodes returned value as a string
+func DecodeString(obj *vm.VMObject) (string, error) {
+	switch obj.Type {
+	case vm.String, vm.Bytes, vm.Number, vm.Enum, vm.Bool, vm.Timestamp:
+		return obj.AsString(), nil
+	}
+
+	return "", unexpectedType(obj, "string")
+}
+
+// DecodeBool decodes returned value as a bool
+func DecodeBool(obj *vm.VMObject) (bool, error) {
+	if obj.Type == vm.Bool {
+		return obj.Data.(bool), nil
+	}
+
+	return false, unexpectedType(obj, "bool")
+}
+
+// DecodeBytes decodes returned value as a byte array
+func DecodeBytes(obj *vm.VMObject) ([]byte, error) {
+	switch obj.Type {
+	case vm.Bytes:
+		return obj.Data.([]byte), nil
+	case vm.String:
+		return []byte(obj.Data.(string)), nil
+	}
+
+	return nil, unexpectedType(obj, "bytes")
+}
+
+// DecodeTime decodes returned value as a time
+func DecodeTime(obj *vm.VMObject) (time.Time, error) {
+	if obj.Type == vm.Timestamp {
+		return time.Unix(int64(obj.Data.(types.Timestamp).Value), 0).UTC(), nil
+	}
+
+	return time.Time{}, unexpectedType(obj, "timestamp")
+}
+
+// DecodeEnum decodes returned value as an enum
+func DecodeEnum(obj *vm.VMObject) (Enum, error) {
+	if obj.Type == vm.Enum {
+		return Enum(obj.Data.(uint32)), nil
+	}
+
+	return 0, unexpectedType(obj, "enum")
+}
+
+// DecodeAddress decodes returned value as an address
+func DecodeAddress(obj *vm.VMObject) (cryptography.Address, error) {
+	a, err := obj.AsAddress()
+	if err != nil {
+		return cryptography.Address{}, fmt.Errorf("cannot decode %s as address: %w", obj.Type, err)
+	}
+
+	return a, nil
+}
+
+// DecodeObject returns returned value as is, it's used for structs
+func DecodeObject(obj *vm.VMObject) (*vm.VMObject, error) {
+	return obj, nil
+}
@@ -0,0 +1,247 @@
+// Package archive stores files on chain as archives. Files are split into blocks, the archive is created
+// with the merkle tree of blocks and blocks are then uploaded one by one. Archives are identified by the
+// root hash of their merkle tree, which is used to verify downloaded files.
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/blockchain"
+	crypto "github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/phantasma-io/phantasma-go/pkg/txbuilder"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+)
+
+const (
+	// BlockSize is the size of archive blocks, every block except the last one is exactly this size
+	BlockSize = 1024 * 1024
+	// DefaultRetries is the default number of times writing or reading a block is retried
+	DefaultRetries = 3
+	// DefaultRetryDelay is the default delay before a block is retried
+	DefaultRetryDelay = time.Second
+)
+
+var (
+	ErrEmptyFile     = errors.New("file is empty")
+	ErrQuotaExceeded = errors.New("storage quota exceeded")
+	ErrHashMismatch  = errors.New("archive hash mismatch")
+)
+
+// Client is a subset of RPC methods used by Storage, it's implemented by rpc.PhantasmaRPC
+type Client interface {
+	GetAccount(address string) (resp.AccountResult, error)
+	GetArchive(hash string) (resp.ArchiveResult, error)
+	WriteArchive(hash string, blockIndex int, content []byte) (bool, error)
+	ReadArchive(hash string, blockIndex int) ([]byte, error)
+	SendRawTransaction(txData string) (string, error)
+}
+
+// Archive is a file split into blocks
+type Archive struct {
+	Name   string
+	Size   int
+	Blocks [][]byte
+	Tree   *MerkleTree
+}
+
+// New splits file into blocks of BlockSize, blocks share memory with data
+func New(name string, data []byte) (Archive, error) {
+	if len(data) == 0 {
+		return Archive{}, ErrEmptyFile
+	}
+
+	blocks := make([][]byte, 0, (len(data)+BlockSize-1)/BlockSize)
+	for offset := 0; offset < len(data); offset += BlockSize {
+		blocks = append(blocks, data[offset:min(offset+BlockSize, len(data))])
+	}
+
+	return Archive{Name: name, Size: len(data), Blocks: blocks, Tree: NewMerkleTree(blocks)}, nil
+}
+
+// Hash returns hash of the archive, it's the root of its merkle tree
+func (a Archive) Hash() crypto.Hash {
+	return a.Tree.Root()
+}
+
+// Progress is the state of an archive upload
+type Progress struct {
+	// Uploaded is the number of blocks stored by node, including blocks uploaded before
+	Uploaded int
+	Total    int
+}
+
+// Done returns true if all blocks are uploaded
+func (p Progress) Done() bool {
+	return p.Uploaded == p.Total
+}
+
+// Storage uploads and downloads archives of a single address
+type Storage struct {
+	Client Client
+	txbuilder.Builder
+
+	// Retries is the number of times a failed block write or read is retried before giving up
+	Retries    int
+	RetryDelay time.Duration
+}
+
+// NewStorage returns storage signing transactions with keys on the main chain of the nexus
+func NewStorage(client Client, nexus string, keys crypto.PhantasmaKeys) *Storage {
+	return &Storage{
+		Client:     client,
+		Builder:    txbuilder.New(nexus, keys),
+		Retries:    DefaultRetries,
+		RetryDelay: DefaultRetryDelay,
+	}
+}
+
+// Quota returns available and used storage of the address in bytes
+func (s *Storage) Quota() (resp.StorageResult, error) {
+	account, err := s.Client.GetAccount(s.Keys.Address().String())
+	if err != nil {
+		return resp.StorageResult{}, err
+	}
+
+	return account.Storage, nil
+}
+
+// CheckQuota returns ErrQuotaExceeded if available storage of the address is less than size.
+// Storage is obtained by staking SOUL.
+func (s *Storage) CheckQuota(size int) error {
+	quota, err := s.Quota()
+	if err != nil {
+		return err
+	}
+
+	if uint(size) > quota.Available {
+		return fmt.Errorf("%w: %d bytes required, %d available", ErrQuotaExceeded, size, quota.Available)
+	}
+
+	return nil
+}
+
+// Create returns signed transaction creating unencrypted archive owned by the address,
+// blocks can be uploaded with Upload once transaction is confirmed
+func (s *Storage) Create(a Archive) (blockchain.Transaction, error) {
+	if err := s.CheckQuota(a.Size); err != nil {
+		return blockchain.Transaction{}, err
+	}
+
+	return s.Transaction(func(sb scriptbuilder.ScriptBuilder) scriptbuilder.ScriptBuilder {
+		return sb.CreateFile(s.Keys.Address(), a.Name, big.NewInt(int64(a.Size)), a.Tree.Bytes(), []byte{})
+	}), nil
+}
+
+// Send broadcasts transaction and returns its hash
+func (s *Storage) Send(tx blockchain.Transaction) (string, error) {
+	return txbuilder.Send(s.Client, tx)
+}
+
+// Upload writes blocks the node is missing. Failed writes are retried, if a block still can't be written
+// upload stops and returns progress with the error, calling Upload again resumes from missing blocks.
+func (s *Storage) Upload(ctx context.Context, a Archive) (Progress, error) {
+	hash := a.Hash().String()
+	info, err := s.Client.GetArchive(hash)
+	if err != nil {
+		return Progress{}, fmt.Errorf("cannot get archive %s: %w", hash, err)
+	}
+
+	if info.BlockCount != len(a.Blocks) {
+		return Progress{}, fmt.Errorf("archive %s has %d blocks, %d expected", hash, info.BlockCount, len(a.Blocks))
+	}
+
+	p := Progress{Uploaded: len(a.Blocks) - len(info.MissingBlocks), Total: len(a.Blocks)}
+	for _, index := range info.MissingBlocks {
+		if index < 0 || index >= len(a.Blocks) {
+			return p, fmt.Errorf("archive %s: invalid missing block %d", hash, index)
+		}
+
+		err := s.retry(ctx, func() error {
+			ok, err := s.Client.WriteArchive(hash, index, a.Blocks[index])
+			if err == nil && !ok {
+				err = errors.New("block is not written")
+			}
+			return err
+		})
+		if err != nil {
+			return p, fmt.Errorf("cannot write block %d of archive %s: %w", index, hash, err)
+		}
+		p.Uploaded++
+	}
+
+	return p, nil
+}
+
+// Download reads all blocks of archive and returns the file. ErrHashMismatch is returned if
+// the file doesn't match the hash.
+func (s *Storage) Download(ctx context.Context, hash string) ([]byte, error) {
+	info, err := s.Client.GetArchive(hash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get archive %s: %w", hash, err)
+	}
+
+	if len(info.MissingBlocks) > 0 {
+		return nil, fmt.Errorf("archive %s is missing %d of %d blocks", hash, len(info.MissingBlocks), info.BlockCount)
+	}
+
+	// size is reported by node, it must fit the blocks before it's used to allocate the file
+	if info.BlockCount <= 0 || info.Size <= uint(info.BlockCount-1)*BlockSize || info.Size > uint(info.BlockCount)*BlockSize {
+		return nil, fmt.Errorf("archive %s: invalid size %d of %d blocks", hash, info.Size, info.BlockCount)
+	}
+
+	data := make([]byte, 0, info.Size)
+	for i := 0; i < info.BlockCount; i++ {
+		var block []byte
+		err := s.retry(ctx, func() error {
+			var err error
+			block, err = s.Client.ReadArchive(hash, i)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot read block %d of archive %s: %w", i, hash, err)
+		}
+		data = append(data, block...)
+	}
+
+	if uint(len(data)) < info.Size {
+		return nil, fmt.Errorf("%w: archive %s has %d bytes, %d expected", ErrHashMismatch, hash, len(data), info.Size)
+	}
+
+	// the last block can be padded by node
+	data = data[:info.Size]
+	a, err := New(info.Name, data)
+	if err != nil {
+		return nil, fmt.Errorf("archive %s: %w", hash, err)
+	}
+
+	if root := a.Hash().String(); root != hash {
+		return nil, fmt.Errorf("%w: %s expected, %s downloaded", ErrHashMismatch, hash, root)
+	}
+
+	return data, nil
+}
+
+// retry calls fn until it succeeds, Retries is exceeded or ctx is done
+func (s *Storage) retry(ctx context.Context, fn func() error) error {
+	var err error
+	for attempt := 0; attempt <= s.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(s.RetryDelay):
+			}
+		}
+
+		if err = fn(); err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
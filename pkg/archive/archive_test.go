@@ -0,0 +1,218 @@
+package archive_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/archive"
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type storedArchive struct {
+	name   string
+	size   int
+	blocks [][]byte
+}
+
+type fakeClient struct {
+	available uint
+	archives  map[string]*storedArchive
+	// failures is the number of times writing or reading a block fails, by block index
+	failures map[int]int
+	writes   []int
+	sent     []string
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{available: 10 * archive.BlockSize, archives: map[string]*storedArchive{}, failures: map[int]int{}}
+}
+
+// create stores archive without blocks, like confirmed CreateFile transaction does
+func (c *fakeClient) create(a archive.Archive) {
+	c.archives[a.Hash().String()] = &storedArchive{name: a.Name, size: a.Size, blocks: make([][]byte, len(a.Blocks))}
+}
+
+func (c *fakeClient) fail(index int) error {
+	if c.failures[index] > 0 {
+		c.failures[index]--
+		return errors.New("connection reset")
+	}
+	return nil
+}
+
+func (c *fakeClient) GetAccount(address string) (resp.AccountResult, error) {
+	return resp.AccountResult{Address: address, Storage: resp.StorageResult{Available: c.available}}, nil
+}
+
+func (c *fakeClient) GetArchive(hash string) (resp.ArchiveResult, error) {
+	a, ok := c.archives[hash]
+	if !ok {
+		return resp.ArchiveResult{}, errors.New("archive not found")
+	}
+
+	r := resp.ArchiveResult{Name: a.name, Hash: hash, Size: uint(a.size), BlockCount: len(a.blocks), MissingBlocks: []int{}}
+	for i, b := range a.blocks {
+		if b == nil {
+			r.MissingBlocks = append(r.MissingBlocks, i)
+		}
+	}
+
+	return r, nil
+}
+
+func (c *fakeClient) WriteArchive(hash string, blockIndex int, content []byte) (bool, error) {
+	if err := c.fail(blockIndex); err != nil {
+		return false, err
+	}
+
+	c.writes = append(c.writes, blockIndex)
+	c.archives[hash].blocks[blockIndex] = bytes.Clone(content)
+	return true, nil
+}
+
+func (c *fakeClient) ReadArchive(hash string, blockIndex int) ([]byte, error) {
+	if err := c.fail(blockIndex); err != nil {
+		return nil, err
+	}
+
+	return c.archives[hash].blocks[blockIndex], nil
+}
+
+func (c *fakeClient) SendRawTransaction(txData string) (string, error) {
+	c.sent = append(c.sent, txData)
+	return "hash", nil
+}
+
+func newStorage(client *fakeClient) *archive.Storage {
+	s := archive.NewStorage(client, "simnet", cryptography.NewPhantasmaKeys(make([]byte, 32)))
+	s.Retries = 1
+	s.RetryDelay = 0
+	s.Now = func() time.Time { return time.Unix(1700000000, 0) }
+	return s
+}
+
+func file(size int) []byte {
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	return data
+}
+
+func TestNew(t *testing.T) {
+	a, err := archive.New("movie.mp4", file(2*archive.BlockSize+100))
+	require.Nil(t, err)
+	require.Len(t, a.Blocks, 3)
+	assert.Len(t, a.Blocks[0], archive.BlockSize)
+	assert.Len(t, a.Blocks[2], 100)
+	assert.Equal(t, 3, a.Tree.Leaves())
+	assert.Equal(t, a.Tree.Root(), a.Hash())
+
+	_, err = archive.New("empty", nil)
+	assert.ErrorIs(t, err, archive.ErrEmptyFile)
+}
+
+func TestCreate(t *testing.T) {
+	client := newFakeClient()
+	s := newStorage(client)
+	a, err := archive.New("notes.txt", file(1000))
+	require.Nil(t, err)
+
+	tx, err := s.Create(a)
+	require.Nil(t, err)
+	assert.NotEmpty(t, tx.Script)
+
+	_, err = s.Send(tx)
+	require.Nil(t, err)
+	assert.Len(t, client.sent, 1)
+
+	client.available = 999
+	_, err = s.Create(a)
+	assert.ErrorIs(t, err, archive.ErrQuotaExceeded)
+}
+
+func TestUploadResume(t *testing.T) {
+	client := newFakeClient()
+	s := newStorage(client)
+	data := file(3*archive.BlockSize + 1)
+	a, err := archive.New("backup.tar", data)
+	require.Nil(t, err)
+
+	_, err = s.Upload(context.Background(), a)
+	require.NotNil(t, err, "archive is not created yet")
+
+	client.create(a)
+	client.failures[2] = 2
+
+	p, err := s.Upload(context.Background(), a)
+	require.NotNil(t, err)
+	assert.Equal(t, archive.Progress{Uploaded: 2, Total: 4}, p)
+	assert.False(t, p.Done())
+
+	p, err = s.Upload(context.Background(), a)
+	require.Nil(t, err)
+	assert.True(t, p.Done())
+	assert.Equal(t, []int{0, 1, 2, 3}, client.writes)
+
+	// nothing is written again once archive is complete
+	p, err = s.Upload(context.Background(), a)
+	require.Nil(t, err)
+	assert.True(t, p.Done())
+	assert.Len(t, client.writes, 4)
+
+	client.failures[1] = 1
+	downloaded, err := s.Download(context.Background(), a.Hash().String())
+	require.Nil(t, err)
+	assert.Equal(t, data, downloaded)
+}
+
+func TestDownloadVerifiesHash(t *testing.T) {
+	client := newFakeClient()
+	s := newStorage(client)
+	a, err := archive.New("notes.txt", file(archive.BlockSize+10))
+	require.Nil(t, err)
+	hash := a.Hash().String()
+
+	client.create(a)
+	_, err = s.Download(context.Background(), hash)
+	require.NotNil(t, err, "blocks are missing")
+
+	_, err = s.Upload(context.Background(), a)
+	require.Nil(t, err)
+
+	// node pads the last block
+	stored := client.archives[hash]
+	stored.blocks[1] = append(stored.blocks[1], 0, 0, 0)
+	data, err := s.Download(context.Background(), hash)
+	require.Nil(t, err)
+	assert.Len(t, data, archive.BlockSize+10)
+
+	stored.blocks[0][5] ^= 0xff
+	_, err = s.Download(context.Background(), hash)
+	assert.ErrorIs(t, err, archive.ErrHashMismatch)
+}
+
+func TestDownloadInvalidSize(t *testing.T) {
+	client := newFakeClient()
+	s := newStorage(client)
+	a, err := archive.New("notes.txt", file(archive.BlockSize+10))
+	require.Nil(t, err)
+	hash := a.Hash().String()
+
+	client.create(a)
+	_, err = s.Upload(context.Background(), a)
+	require.Nil(t, err)
+
+	for _, size := range []int{0, archive.BlockSize, 2*archive.BlockSize + 1, 1 << 40} {
+		client.archives[hash].size = size
+		_, err = s.Download(context.Background(), hash)
+		assert.NotNil(t, err, size)
+		assert.NotErrorIs(t, err, archive.ErrHashMismatch, size)
+	}
+}
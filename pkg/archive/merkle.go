@@ -0,0 +1,116 @@
+package archive
+
+import (
+	"bytes"
+	"fmt"
+
+	crypto "github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	hashing "github.com/phantasma-io/phantasma-go/pkg/util/hashing"
+)
+
+// MerkleTree is a merkle tree of archive blocks. Leaves are SHA256 hashes of blocks, parents are SHA256
+// hashes of concatenated children, a node without a sibling is paired with itself. Nodes are stored level
+// by level, leaves first and root last.
+type MerkleTree struct {
+	nodes  []crypto.Hash
+	leaves int
+}
+
+// NewMerkleTree returns merkle tree of blocks, there should be at least one block
+func NewMerkleTree(blocks [][]byte) *MerkleTree {
+	nodes := make([]crypto.Hash, 0, 2*len(blocks))
+	for _, b := range blocks {
+		nodes = append(nodes, hashOf(b))
+	}
+
+	for level := nodes; len(level) > 1; level = nodes[len(nodes)-(len(level)+1)/2:] {
+		for i := 0; i < len(level); i += 2 {
+			left, right := level[i], level[i]
+			if i+1 < len(level) {
+				right = level[i+1]
+			}
+			pair := make([]byte, 0, 2*crypto.HashLength)
+			pair = append(pair, left.Bytes()...)
+			nodes = append(nodes, hashOf(append(pair, right.Bytes()...)))
+		}
+	}
+
+	return &MerkleTree{nodes: nodes, leaves: len(blocks)}
+}
+
+// Root returns root hash of the tree, it's the hash of the archive
+func (t *MerkleTree) Root() crypto.Hash {
+	if len(t.nodes) == 0 {
+		return crypto.Hash{}
+	}
+
+	return t.nodes[len(t.nodes)-1]
+}
+
+// Leaves returns the number of blocks of the tree
+func (t *MerkleTree) Leaves() int {
+	return t.leaves
+}
+
+// VerifyBlock returns true if block is the block with index of the tree
+func (t *MerkleTree) VerifyBlock(index int, block []byte) bool {
+	if index < 0 || index >= t.Leaves() {
+		return false
+	}
+
+	return bytes.Equal(t.nodes[index].Bytes(), hashOf(block).Bytes())
+}
+
+// Bytes returns serialized tree as expected by CreateFile method of the storage contract
+func (t *MerkleTree) Bytes() []byte {
+	bw := io.NewBufBinWriter()
+	t.Serialize(bw.BinWriter)
+	return bw.Bytes()
+}
+
+// Serialize implements ther Serializable interface
+func (t *MerkleTree) Serialize(writer *io.BinWriter) {
+	writer.WriteVarUint(uint64(len(t.nodes)))
+	for i := range t.nodes {
+		t.nodes[i].Serialize(writer)
+	}
+}
+
+// Deserialize implements ther Serializable interface
+func (t *MerkleTree) Deserialize(reader *io.BinReader) {
+	count := reader.ReadVarUint()
+	if reader.Err != nil {
+		return
+	}
+
+	t.nodes = make([]crypto.Hash, 0, min(count, 1024))
+	for i := uint64(0); i < count && reader.Err == nil; i++ {
+		var h crypto.Hash
+		h.Deserialize(reader)
+		t.nodes = append(t.nodes, h)
+	}
+
+	t.leaves = 0
+	for nodeCount(t.leaves) < len(t.nodes) {
+		t.leaves++
+	}
+	if reader.Err == nil && nodeCount(t.leaves) != len(t.nodes) {
+		reader.Err = fmt.Errorf("invalid merkle tree of %d nodes", len(t.nodes))
+	}
+}
+
+// nodeCount returns the number of nodes of a tree with leaves
+func nodeCount(leaves int) int {
+	count := leaves
+	for level := leaves; level > 1; level = (level + 1) / 2 {
+		count += (level + 1) / 2
+	}
+
+	return count
+}
+
+func hashOf(data []byte) crypto.Hash {
+	h, _ := crypto.HashFromBytes(hashing.Sha256(data))
+	return h
+}
@@ -0,0 +1,74 @@
+package archive_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/phantasma-io/phantasma-go/pkg/archive"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	hashing "github.com/phantasma-io/phantasma-go/pkg/util/hashing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pairHash(left, right []byte) []byte {
+	return hashing.Sha256(append(append([]byte{}, left...), right...))
+}
+
+func TestMerkleTreeRoot(t *testing.T) {
+	a, b, c := []byte("a"), []byte("b"), []byte("c")
+	ha, hb, hc := hashing.Sha256(a), hashing.Sha256(b), hashing.Sha256(c)
+
+	tree := archive.NewMerkleTree([][]byte{a})
+	assert.Equal(t, ha, tree.Root().Bytes())
+	assert.Equal(t, 1, tree.Leaves())
+
+	tree = archive.NewMerkleTree([][]byte{a, b})
+	assert.Equal(t, pairHash(ha, hb), tree.Root().Bytes())
+
+	// the third leaf has no sibling and is paired with itself
+	tree = archive.NewMerkleTree([][]byte{a, b, c})
+	assert.Equal(t, pairHash(pairHash(ha, hb), pairHash(hc, hc)), tree.Root().Bytes())
+	assert.Equal(t, 3, tree.Leaves())
+
+	assert.True(t, tree.VerifyBlock(2, c))
+	assert.False(t, tree.VerifyBlock(1, c))
+	assert.False(t, tree.VerifyBlock(3, c))
+}
+
+func TestMerkleTreeSerialization(t *testing.T) {
+	tree := archive.NewMerkleTree([][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("d"), []byte("e")})
+	data := tree.Bytes()
+	// 5 leaves, 3 + 2 + 1 parents, each hash is prefixed with its length
+	require.Len(t, data, 1+11*33)
+
+	var decoded archive.MerkleTree
+	br := io.NewBinReaderFromBuf(data)
+	decoded.Deserialize(br)
+	require.Nil(t, br.Err)
+	assert.Equal(t, tree.Root(), decoded.Root())
+	assert.Equal(t, 5, decoded.Leaves())
+
+	// 4 nodes can't form a tree
+	br = io.NewBinReaderFromBuf(append([]byte{4}, data[1:1+4*33]...))
+	decoded.Deserialize(br)
+	assert.NotNil(t, br.Err)
+}
+
+func TestMerkleTreeVectors(t *testing.T) {
+	// root of a single block is its SHA256, this is the FIPS 180-2 vector of "abc"
+	tree := archive.NewMerkleTree([][]byte{[]byte("abc")})
+	assert.Equal(t, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", hex.EncodeToString(tree.Root().Bytes()))
+	assert.Equal(t, "0120ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", hex.EncodeToString(tree.Bytes()))
+
+	// leaves a, b, c, parents ab and cc, root; hash strings are reversed like other hashes
+	tree = archive.NewMerkleTree([][]byte{[]byte("a"), []byte("b"), []byte("c")})
+	assert.Equal(t, "befa8fa176daafad3923026544fd6a2e59b5be16430c47b12d4ac16aef371ad3", tree.Root().String())
+	assert.Equal(t, "06"+
+		"20ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb"+
+		"203e23e8160039594a33894f6564e1b1348bbd7a0088d42c4acb73eeaed59c009d"+
+		"202e7d2c03a9507ae265ecf5b5356885a53393a2029d241394997265a1a25aefc6"+
+		"20e5a01fee14e0ed5c48714f22180f25ad8365b53f9779f79dc4a3d7e93963f94a"+
+		"20a3e333fbee455b9a054cf05077f0f9d45b91bd13db4cd4a3681ec47455af085c"+
+		"20d31a37ef6ac14a2db1470c4316beb5592e6afd4465022339adafda76a18ffabe", hex.EncodeToString(tree.Bytes()))
+}
@@ -1,6 +1,7 @@
 package blockchain
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
@@ -96,10 +97,15 @@ func (tx *Transaction) Deserialize(reader *io.BinReader) {
 	tx.Payload = reader.ReadVarBytes()
 
 	signatureCount := int(reader.ReadVarUint())
-	if signatureCount > 0 {
-		reader.ReadArray(&tx.Signatures, signatureCount)
-	} else {
-		tx.Signatures = []crypto.Signature{}
+	tx.Signatures = []crypto.Signature{}
+	for i := 0; i < signatureCount && reader.Err == nil; i++ {
+		kind := crypto.SignatureKind(reader.ReadB())
+		switch kind {
+		case crypto.Ed25519:
+			tx.Signatures = append(tx.Signatures, crypto.NewEd25519Signature(reader.ReadVarBytes()))
+		default:
+			reader.Err = fmt.Errorf("unsupported signature kind %d", kind)
+		}
 	}
 	tx.updateHash()
 }
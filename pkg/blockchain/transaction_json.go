@@ -0,0 +1,109 @@
+package blockchain
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	crypto "github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+)
+
+type jsonSignature struct {
+	Kind string `json:"kind"`
+	Data string `json:"data"`
+}
+
+// jsonTransaction is a JSON representation of a transaction, byte arrays are hex encoded
+type jsonTransaction struct {
+	Hash       crypto.Hash     `json:"hash"`
+	NexusName  string          `json:"nexus"`
+	ChainName  string          `json:"chain"`
+	Script     string          `json:"script"`
+	Expiration uint32          `json:"expiration"`
+	Payload    string          `json:"payload"`
+	Signatures []jsonSignature `json:"signatures"`
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (tx Transaction) MarshalJSON() ([]byte, error) {
+	result := jsonTransaction{
+		Hash:       tx.Hash,
+		NexusName:  tx.NexusName,
+		ChainName:  tx.ChainName,
+		Script:     hex.EncodeToString(tx.Script),
+		Expiration: tx.Expiration,
+		Payload:    hex.EncodeToString(tx.Payload),
+		Signatures: []jsonSignature{},
+	}
+
+	for _, s := range tx.Signatures {
+		result.Signatures = append(result.Signatures, jsonSignature{
+			Kind: s.Kind().String(),
+			Data: hex.EncodeToString(s.Bytes()),
+		})
+	}
+
+	return json.Marshal(result)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Hash is recalculated
+// from transaction fields, it's an error if it doesn't match the hash in JSON.
+func (tx *Transaction) UnmarshalJSON(data []byte) error {
+	var j jsonTransaction
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+
+	script, err := hex.DecodeString(j.Script)
+	if err != nil {
+		return fmt.Errorf("invalid script: %w", err)
+	}
+
+	payload, err := hex.DecodeString(j.Payload)
+	if err != nil {
+		return fmt.Errorf("invalid payload: %w", err)
+	}
+
+	result := Transaction{
+		NexusName:  j.NexusName,
+		ChainName:  j.ChainName,
+		Script:     script,
+		Expiration: j.Expiration,
+		Payload:    payload,
+		Signatures: []crypto.Signature{},
+	}
+
+	for _, s := range j.Signatures {
+		kind, err := crypto.ParseSignatureKind(s.Kind)
+		if err != nil {
+			return err
+		}
+
+		bytes, err := hex.DecodeString(s.Data)
+		if err != nil {
+			return fmt.Errorf("invalid signature: %w", err)
+		}
+
+		// signature data is serialized same way as in binary transaction, with length prefix
+		br := io.NewBinReaderFromBuf(bytes)
+		switch kind {
+		case crypto.Ed25519:
+			signature := crypto.NewEd25519Signature(br.ReadVarBytes())
+			if br.Err != nil {
+				return fmt.Errorf("invalid signature: %w", br.Err)
+			}
+			result.Signatures = append(result.Signatures, signature)
+		default:
+			return fmt.Errorf("unsupported signature kind %s", kind)
+		}
+	}
+
+	result.updateHash()
+	if j.Hash.Size() != 0 && j.Hash.String() != result.Hash.String() {
+		return fmt.Errorf("transaction hash mismatch, expected %s, got %s", result.Hash, j.Hash)
+	}
+
+	*tx = result
+	return nil
+}
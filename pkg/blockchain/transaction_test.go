@@ -1,6 +1,8 @@
 package blockchain
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
@@ -83,5 +85,38 @@ func TestTxSerialization2(t *testing.T) {
 	assert.Equal(t, tx, newTx)
 }
 
+func TestTxSignedSerialization(t *testing.T) {
+	tx := NewTransaction("mainnet", "main", []byte{0x01, 0x02, 0x03}, 1623519055, nil)
+	kp := cryptography.NewPhantasmaKeys([]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, 0x28, 0x29, 0x30, 0x31, 0x32})
+	tx.Sign(kp)
+
+	newTx := io.Deserialize[*Transaction](tx.Bytes())
+
+	assert.Equal(t, tx.Hash, newTx.Hash)
+	assert.Equal(t, tx.Bytes(), newTx.Bytes())
+	assert.True(t, newTx.IsSignedBy([]cryptography.Address{kp.Address()}))
+}
+
+func TestTxJSON(t *testing.T) {
+	tx := NewTransaction("mainnet", "main", []byte{0x01, 0x02, 0x03}, 1623519055, []byte("payload"))
+	kp := cryptography.NewPhantasmaKeys([]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, 0x28, 0x29, 0x30, 0x31, 0x32})
+	tx.Sign(kp)
+
+	data, err := json.Marshal(tx)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"hash":"`+tx.Hash.String()+`"`)
+	assert.Contains(t, string(data), `"script":"010203"`)
+	assert.Contains(t, string(data), `"signatures":[{"kind":"Ed25519","data":"`)
+
+	var decoded Transaction
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, tx.Bytes(), decoded.Bytes())
+	assert.Equal(t, tx.Hash, decoded.Hash)
+	assert.True(t, decoded.IsSignedBy([]cryptography.Address{kp.Address()}))
+
+	tampered := strings.Replace(string(data), `"script":"010203"`, `"script":"010204"`, 1)
+	assert.NotNil(t, json.Unmarshal([]byte(tampered), &decoded))
+}
+
 //TODO
 //func TestTxMine(t *testing.T) {}
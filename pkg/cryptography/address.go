@@ -96,6 +96,10 @@ func FromString(s string) (Address, error) {
 		return Address{}, err
 	}
 
+	if len(data) != Length {
+		return Address{}, fmt.Errorf("Invalid address length %d", len(data))
+	}
+
 	address := NewAddress(data)
 
 	switch prefix := s[:1]; prefix {
@@ -226,3 +230,31 @@ func (a *Address) Serialize(writer *io.BinWriter) {
 func (a *Address) Deserialize(reader *io.BinReader) {
 	a.data = reader.ReadVarBytes()
 }
+
+// MarshalText implements the encoding.TextMarshaler interface, address is encoded as P.../S.../X... text,
+// null address is encoded as "NULL" and empty address as an empty string
+func (a Address) MarshalText() ([]byte, error) {
+	if a.data == nil {
+		return []byte{}, nil
+	}
+
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (a *Address) UnmarshalText(text []byte) error {
+	switch s := string(text); s {
+	case "":
+		*a = Address{}
+	case "NULL":
+		*a = NullAddress()
+	default:
+		address, err := FromString(s)
+		if err != nil {
+			return err
+		}
+		*a = address
+	}
+
+	return nil
+}
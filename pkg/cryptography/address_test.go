@@ -1,6 +1,7 @@
 package cryptography
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -19,6 +20,18 @@ func TestAddressFromString(t *testing.T) {
 	assert.Equal(t, address.Bytes(), []byte{0x01, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, 0x28, 0x29, 0x30, 0x31, 0x32, 0x33})
 }
 
+func TestAddressFromStringLength(t *testing.T) {
+	// valid base58 with the user prefix, but only 33 bytes of data
+	short := NewAddress(make([]byte, Length)).String()
+	short = short[:len(short)-2]
+
+	_, err := FromString(short)
+	assert.ErrorContains(t, err, "Invalid address length")
+
+	_, err = FromString("P" + short[1:] + "111")
+	assert.NotNil(t, err)
+}
+
 func TestAddressKind(t *testing.T) {
 	user := NewAddress([]byte{0x01, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, 0x28, 0x29, 0x30, 0x31, 0x32, 0x33})
 	system := NewAddress([]byte{0x02, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, 0x28, 0x29, 0x30, 0x31, 0x32, 0x33})
@@ -27,3 +40,20 @@ func TestAddressKind(t *testing.T) {
 	assert.Equal(t, system.Kind(), System)
 	assert.Equal(t, interop.Kind(), Interop)
 }
+
+func TestAddressJSON(t *testing.T) {
+	address, _ := FromString("P2KM9FjYrDXnPPAynLXAHdQ8wYz8de9VbDeybrLepnw6C5x")
+
+	data, err := json.Marshal(map[string]Address{"user": address, "null": NullAddress(), "empty": {}})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"empty":"","null":"NULL","user":"P2KM9FjYrDXnPPAynLXAHdQ8wYz8de9VbDeybrLepnw6C5x"}`, string(data))
+
+	var decoded map[string]Address
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, address.Bytes(), decoded["user"].Bytes())
+	assert.True(t, decoded["null"].IsNull())
+	assert.Nil(t, decoded["empty"].Bytes())
+
+	var a Address
+	assert.NotNil(t, json.Unmarshal([]byte(`"P2KM9"`), &a))
+}
@@ -3,6 +3,7 @@ package cryptography
 import (
 	"bytes"
 	"encoding/hex"
+	"fmt"
 	"slices"
 	"strings"
 
@@ -49,8 +50,8 @@ func ParseHash(s string) (Hash, error) {
 		s = s[2:]
 	}
 
-	if len(s) < 64 || len(s) == 0 {
-		panic("string has wrong format")
+	if len(s) != HashLength*2 {
+		return Hash{}, fmt.Errorf("invalid hash length %d", len(s))
 	}
 
 	bytes, err := hex.DecodeString(s)
@@ -135,3 +136,28 @@ func (h *Hash) Serialize(writer *io.BinWriter) {
 func (h *Hash) Deserialize(reader *io.BinReader) {
 	h._data = reader.ReadVarBytes()
 }
+
+// MarshalText implements the encoding.TextMarshaler interface, hash is encoded same as String() does
+func (h Hash) MarshalText() ([]byte, error) {
+	if h._data == nil {
+		return []byte{}, nil
+	}
+
+	return []byte(h.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (h *Hash) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*h = Hash{}
+		return nil
+	}
+
+	hash, err := ParseHash(string(text))
+	if err != nil {
+		return err
+	}
+
+	*h = hash
+	return nil
+}
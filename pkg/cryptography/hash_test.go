@@ -1,6 +1,7 @@
 package cryptography
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -29,3 +30,17 @@ func TestHashParse(t *testing.T) {
 	hash, _ := ParseHash("e4e5697cae3e55c6ebb185cadbe6c957109b11b1519b284c76892433151bcb4b")
 	assert.Equal(t, "e4e5697cae3e55c6ebb185cadbe6c957109b11b1519b284c76892433151bcb4b", hash.String())
 }
+
+func TestHashJSON(t *testing.T) {
+	hash, _ := ParseHash("e4e5697cae3e55c6ebb185cadbe6c957109b11b1519b284c76892433151bcb4b")
+
+	data, err := json.Marshal(hash)
+	assert.Nil(t, err)
+	assert.Equal(t, `"e4e5697cae3e55c6ebb185cadbe6c957109b11b1519b284c76892433151bcb4b"`, string(data))
+
+	var decoded Hash
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, hash.Bytes(), decoded.Bytes())
+
+	assert.NotNil(t, json.Unmarshal([]byte(`"e4e5"`), &decoded))
+}
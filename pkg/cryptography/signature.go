@@ -1,6 +1,10 @@
 package cryptography
 
-import "github.com/phantasma-io/phantasma-go/pkg/io"
+import (
+	"fmt"
+
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+)
 
 // SignatureKind type
 type SignatureKind uint
@@ -24,3 +28,29 @@ type Signature interface {
 	Deserialize(*io.BinReader)
 	Bytes() []byte
 }
+
+var signatureKindLookup = map[SignatureKind]string{
+	None:    "None",
+	Ed25519: "Ed25519",
+	ECDSA:   "ECDSA",
+	Ring:    "Ring",
+}
+
+func (k SignatureKind) String() string {
+	if s, ok := signatureKindLookup[k]; ok {
+		return s
+	}
+
+	return "Unknown"
+}
+
+// ParseSignatureKind returns signature kind by its name
+func ParseSignatureKind(s string) (SignatureKind, error) {
+	for k, name := range signatureKindLookup {
+		if name == s {
+			return k, nil
+		}
+	}
+
+	return None, fmt.Errorf("unknown signature kind %q", s)
+}
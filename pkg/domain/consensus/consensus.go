@@ -0,0 +1,243 @@
+// Package consensus contains polls of the consensus contract and helpers to build valid votes.
+package consensus
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/phantasma-io/phantasma-go/pkg/domain/types"
+)
+
+type ConsensusMode uint
+type PollState uint
+
+const (
+	Unanimity  ConsensusMode = 0
+	Majority   ConsensusMode = 1
+	Popularity ConsensusMode = 2
+	Ranking    ConsensusMode = 3
+)
+
+var consensusModeLookup = map[ConsensusMode]string{
+	Unanimity:  `Unanimity`,
+	Majority:   `Majority`,
+	Popularity: `Popularity`,
+	Ranking:    `Ranking`,
+}
+
+func (m ConsensusMode) String() string {
+	return consensusModeLookup[m]
+}
+
+// ParseConsensusMode returns consensus mode by its name
+func ParseConsensusMode(s string) (ConsensusMode, error) {
+	for m, name := range consensusModeLookup {
+		if name == s {
+			return m, nil
+		}
+	}
+
+	return Unanimity, fmt.Errorf("unknown consensus mode %q", s)
+}
+
+const (
+	Inactive  PollState = 0
+	Active    PollState = 1
+	Consensus PollState = 2
+	Failure   PollState = 3
+)
+
+var pollStateLookup = map[PollState]string{
+	Inactive:  `Inactive`,
+	Active:    `Active`,
+	Consensus: `Consensus`,
+	Failure:   `Failure`,
+}
+
+func (s PollState) String() string {
+	return pollStateLookup[s]
+}
+
+// ParsePollState returns poll state by its name
+func ParsePollState(s string) (PollState, error) {
+	for state, name := range pollStateLookup {
+		if name == s {
+			return state, nil
+		}
+	}
+
+	return Inactive, fmt.Errorf("unknown poll state %q", s)
+}
+
+// TotalPercentage is the sum of percentages of votes cast by an address in a poll
+const TotalPercentage = 100
+
+// ErrInvalidVote is returned when votes can't be accepted by a poll
+var ErrInvalidVote = errors.New("invalid vote")
+
+// PollValue is a poll choice with its votes
+type PollValue struct {
+	Value   []byte   `vm:"value"`
+	Ranking *big.Int `vm:"ranking"`
+	Votes   *big.Int `vm:"votes"`
+}
+
+// Text returns value of the choice as text, choices of most polls are UTF-8 strings
+func (v PollValue) Text() string {
+	return string(v.Value)
+}
+
+// PollVote is a vote for the choice with Index, an address can split its votes between
+// several choices with percentages summing up to TotalPercentage
+type PollVote struct {
+	Index      *big.Int `vm:"index"`
+	Percentage *big.Int `vm:"percentage"`
+}
+
+// ConsensusPoll is a poll returned by GetConsensusPoll method of consensus contract
+type ConsensusPoll struct {
+	Subject        string          `vm:"subject"`
+	Organization   string          `vm:"organization"`
+	Mode           ConsensusMode   `vm:"mode"`
+	State          PollState       `vm:"state"`
+	Entries        []PollValue     `vm:"entries"`
+	Round          *big.Int        `vm:"round"`
+	StartTime      types.Timestamp `vm:"startTime"`
+	EndTime        types.Timestamp `vm:"endTime"`
+	ChoicesPerUser *big.Int        `vm:"choicesPerUser"`
+	TotalVotes     *big.Int        `vm:"totalVotes"`
+}
+
+// IsActive returns true if poll accepts votes at the given time
+func (p ConsensusPoll) IsActive(now types.Timestamp) bool {
+	if p.State == Consensus || p.State == Failure {
+		return false
+	}
+
+	return now.Value >= p.StartTime.Value && now.Value < p.EndTime.Value
+}
+
+// Tally returns poll entries ordered by votes, most voted first, entries with equal votes keep their order
+func (p ConsensusPoll) Tally() []PollValue {
+	tally := make([]PollValue, len(p.Entries))
+	copy(tally, p.Entries)
+	sort.SliceStable(tally, func(i, j int) bool {
+		return votesOf(tally[i]).Cmp(votesOf(tally[j])) > 0
+	})
+
+	return tally
+}
+
+// Choice returns index of the entry with the given value, false is returned if there is no such entry
+func (p ConsensusPoll) Choice(value string) (int, bool) {
+	for i, e := range p.Entries {
+		if string(e.Value) == value {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// ValidateVotes checks that votes can be cast in the poll: choices exist and aren't repeated,
+// their number doesn't exceed ChoicesPerUser and percentages sum up to TotalPercentage
+func (p ConsensusPoll) ValidateVotes(votes []PollVote) error {
+	if len(votes) == 0 {
+		return fmt.Errorf("%w: no choices", ErrInvalidVote)
+	}
+
+	if p.ChoicesPerUser != nil && p.ChoicesPerUser.Sign() > 0 && big.NewInt(int64(len(votes))).Cmp(p.ChoicesPerUser) > 0 {
+		return fmt.Errorf("%w: %d choices, poll allows %s", ErrInvalidVote, len(votes), p.ChoicesPerUser)
+	}
+
+	seen := map[int64]bool{}
+	total := big.NewInt(0)
+	for _, v := range votes {
+		if v.Index == nil || v.Index.Sign() < 0 || v.Index.Cmp(big.NewInt(int64(len(p.Entries)))) >= 0 {
+			return fmt.Errorf("%w: unknown choice %v", ErrInvalidVote, v.Index)
+		}
+		if seen[v.Index.Int64()] {
+			return fmt.Errorf("%w: choice %s is repeated", ErrInvalidVote, v.Index)
+		}
+		seen[v.Index.Int64()] = true
+
+		if v.Percentage == nil || v.Percentage.Sign() <= 0 {
+			return fmt.Errorf("%w: invalid percentage %v of choice %s", ErrInvalidVote, v.Percentage, v.Index)
+		}
+		total.Add(total, v.Percentage)
+	}
+
+	if total.Cmp(big.NewInt(TotalPercentage)) != 0 {
+		return fmt.Errorf("%w: percentages sum up to %s", ErrInvalidVote, total)
+	}
+
+	return nil
+}
+
+// SingleVote returns a vote giving all percentage to one choice
+func SingleVote(index int) []PollVote {
+	return []PollVote{{Index: big.NewInt(int64(index)), Percentage: big.NewInt(TotalPercentage)}}
+}
+
+// RankedVotes returns votes for choices ordered by preference, most preferred first. Percentages
+// decrease linearly, with n choices the first one gets n parts and the last one gets a single part.
+// Shares are rounded with the largest remainder method and every choice gets at least one percent,
+// so only the first TotalPercentage choices are voted for.
+func RankedVotes(indexes ...int) []PollVote {
+	if len(indexes) > TotalPercentage {
+		indexes = indexes[:TotalPercentage]
+	}
+
+	n := len(indexes)
+	if n == 0 {
+		return []PollVote{}
+	}
+	parts := n * (n + 1) / 2
+
+	shares := make([]int, n)
+	remainders := make([]int, n)
+	order := make([]int, n)
+	total := 0
+	for i := range indexes {
+		shares[i] = TotalPercentage * (n - i) / parts
+		remainders[i] = TotalPercentage * (n - i) % parts
+		order[i] = i
+		total += shares[i]
+	}
+
+	// rounding leftover goes to the largest remainders, more preferred choices win ties
+	sort.SliceStable(order, func(i, j int) bool { return remainders[order[i]] > remainders[order[j]] })
+	for _, i := range order[:TotalPercentage-total] {
+		shares[i]++
+	}
+
+	// least preferred choices left without a percent take it from the last of the largest shares,
+	// which keeps percentages ordered by preference
+	for i := n - 1; i >= 0 && shares[i] == 0; i-- {
+		largest := 0
+		for j := range shares {
+			if shares[j] >= shares[largest] {
+				largest = j
+			}
+		}
+		shares[largest]--
+		shares[i]++
+	}
+
+	votes := make([]PollVote, n)
+	for i, index := range indexes {
+		votes[i] = PollVote{Index: big.NewInt(int64(index)), Percentage: big.NewInt(int64(shares[i]))}
+	}
+
+	return votes
+}
+
+func votesOf(v PollValue) *big.Int {
+	if v.Votes == nil {
+		return big.NewInt(0)
+	}
+
+	return v.Votes
+}
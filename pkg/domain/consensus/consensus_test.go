@@ -0,0 +1,168 @@
+package consensus_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/phantasma-io/phantasma-go/pkg/domain/consensus"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event/eventtest"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/types"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPoll() consensus.ConsensusPoll {
+	return consensus.ConsensusPoll{
+		Subject:      "elections",
+		Organization: "validators",
+		Mode:         consensus.Ranking,
+		State:        consensus.Active,
+		Entries: []consensus.PollValue{
+			{Value: []byte("alice"), Votes: big.NewInt(10)},
+			{Value: []byte("bob"), Votes: big.NewInt(30)},
+			{Value: []byte("carol"), Votes: big.NewInt(10)},
+		},
+		Round:          big.NewInt(1),
+		StartTime:      types.Timestamp{Value: 1000},
+		EndTime:        types.Timestamp{Value: 2000},
+		ChoicesPerUser: big.NewInt(3),
+		TotalVotes:     big.NewInt(50),
+	}
+}
+
+func TestParse(t *testing.T) {
+	mode, err := consensus.ParseConsensusMode("Popularity")
+	require.Nil(t, err)
+	assert.Equal(t, consensus.Popularity, mode)
+	assert.Equal(t, "Ranking", consensus.Ranking.String())
+
+	state, err := consensus.ParsePollState("Failure")
+	require.Nil(t, err)
+	assert.Equal(t, consensus.Failure, state)
+
+	_, err = consensus.ParsePollState("Closed")
+	assert.NotNil(t, err)
+}
+
+func TestPollUnmarshal(t *testing.T) {
+	poll := newPoll()
+
+	obj, err := vm.Marshal(poll)
+	require.Nil(t, err)
+	// enums are stored as Enum objects by the contract
+	s := obj.Data.(*vm.VMStruct)
+	s.Set(*vm.NewString("mode"), *vm.NewEnum(uint32(consensus.Ranking)))
+
+	var decoded consensus.ConsensusPoll
+	require.Nil(t, vm.Unmarshal(obj, &decoded))
+	assert.Equal(t, poll, decoded)
+}
+
+func TestPollQueries(t *testing.T) {
+	poll := newPoll()
+
+	assert.False(t, poll.IsActive(types.Timestamp{Value: 999}))
+	assert.True(t, poll.IsActive(types.Timestamp{Value: 1000}))
+	assert.False(t, poll.IsActive(types.Timestamp{Value: 2000}))
+	poll.State = consensus.Consensus
+	assert.False(t, poll.IsActive(types.Timestamp{Value: 1500}))
+
+	tally := poll.Tally()
+	texts := []string{}
+	for _, v := range tally {
+		texts = append(texts, v.Text())
+	}
+	assert.Equal(t, []string{"bob", "alice", "carol"}, texts)
+	assert.Equal(t, "alice", poll.Entries[0].Text())
+
+	index, ok := poll.Choice("carol")
+	assert.True(t, ok)
+	assert.Equal(t, 2, index)
+	_, ok = poll.Choice("dave")
+	assert.False(t, ok)
+}
+
+func TestVotes(t *testing.T) {
+	poll := newPoll()
+
+	votes := consensus.RankedVotes(1, 2, 0)
+	require.Len(t, votes, 3)
+	assert.Equal(t, big.NewInt(50), votes[0].Percentage)
+	assert.Equal(t, big.NewInt(33), votes[1].Percentage)
+	assert.Equal(t, big.NewInt(17), votes[2].Percentage)
+	assert.Nil(t, poll.ValidateVotes(votes))
+
+	assert.Nil(t, poll.ValidateVotes(consensus.SingleVote(2)))
+	assert.Equal(t, big.NewInt(100), consensus.RankedVotes(0)[0].Percentage)
+
+	for _, invalid := range [][]consensus.PollVote{
+		nil,
+		consensus.SingleVote(3),
+		consensus.RankedVotes(0, 0),
+		{{Index: big.NewInt(0), Percentage: big.NewInt(50)}},
+		{{Index: big.NewInt(0), Percentage: big.NewInt(0)}, {Index: big.NewInt(1), Percentage: big.NewInt(100)}},
+	} {
+		assert.True(t, errors.Is(poll.ValidateVotes(invalid), consensus.ErrInvalidVote), invalid)
+	}
+
+	poll.ChoicesPerUser = big.NewInt(2)
+	assert.True(t, errors.Is(poll.ValidateVotes(votes), consensus.ErrInvalidVote))
+}
+
+func TestRankedVotesOfManyChoices(t *testing.T) {
+	poll := consensus.ConsensusPoll{}
+	for i := 0; i < 120; i++ {
+		poll.Entries = append(poll.Entries, consensus.PollValue{Value: []byte{byte(i)}})
+	}
+
+	for n := 1; n <= len(poll.Entries); n++ {
+		indexes := make([]int, n)
+		for i := range indexes {
+			indexes[i] = i
+		}
+
+		votes := consensus.RankedVotes(indexes...)
+		require.Len(t, votes, min(n, consensus.TotalPercentage))
+		require.Nil(t, poll.ValidateVotes(votes), "%d choices", n)
+		for i := 1; i < len(votes); i++ {
+			assert.True(t, votes[i-1].Percentage.Cmp(votes[i].Percentage) >= 0, "%d choices: %v", n, votes)
+		}
+	}
+
+	// 14 choices need 105 parts, the last one still gets a percent
+	votes := consensus.RankedVotes(0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13)
+	assert.Equal(t, big.NewInt(13), votes[0].Percentage)
+	assert.Equal(t, big.NewInt(1), votes[13].Percentage)
+
+	assert.Empty(t, consensus.RankedVotes())
+}
+
+func TestPollEventsFromBlock(t *testing.T) {
+	events, err := consensus.PollEventsFromBlock(resp.BlockResult{Height: 5, Txs: []resp.TransactionResult{
+		{Hash: "A", State: "Halt", Events: []resp.EventResult{
+			eventtest.StringEvent(event.PollCreated, "consensus", "elections"),
+			{Kind: event.TokenSend.String(), Data: "00"},
+		}},
+		{Hash: "B", State: "Fault", Events: []resp.EventResult{
+			eventtest.StringEvent(event.PollVote, "voter", "elections"),
+		}},
+		{Hash: "C", State: "Halt", Events: []resp.EventResult{
+			eventtest.StringEvent(event.PollVote, "voter", "elections"),
+		}},
+	}, Events: []resp.EventResult{
+		eventtest.StringEvent(event.PollClosed, "consensus", "elections"),
+	}})
+	require.Nil(t, err)
+	assert.Equal(t, []consensus.PollEvent{
+		{Kind: event.PollCreated, Address: "consensus", Subject: "elections", Location: event.Location{Height: 5, TxHash: "A"}},
+		{Kind: event.PollVote, Address: "voter", Subject: "elections", Location: event.Location{Height: 5, TxHash: "C"}},
+		{Kind: event.PollClosed, Address: "consensus", Subject: "elections", Location: event.Location{Height: 5}},
+	}, events)
+
+	_, _, err = consensus.ParsePollEvent(resp.EventResult{Kind: event.PollClosed.String(), Data: "zz"})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,29 @@
+package consensus
+
+import (
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+)
+
+// PollEvent is a PollCreated, PollClosed or PollVote event, data of these events is the poll subject
+type PollEvent struct {
+	Kind event.EventKind
+	// Address is the voter of PollVote events and the consensus contract for other events
+	Address string
+	Subject string
+
+	event.Location
+}
+
+// ParsePollEvent decodes poll event, false is returned for other events
+func ParsePollEvent(e resp.EventResult) (PollEvent, bool, error) {
+	return event.Decode(e, func(kind event.EventKind, br *io.BinReader) PollEvent {
+		return PollEvent{Kind: kind, Address: e.Address, Subject: br.ReadString()}
+	}, event.PollCreated, event.PollClosed, event.PollVote)
+}
+
+// PollEventsFromBlock returns poll events of successful block transactions and of the block itself, in order
+func PollEventsFromBlock(block resp.BlockResult) ([]PollEvent, error) {
+	return event.FromBlock(block, ParsePollEvent)
+}
@@ -46,8 +46,9 @@ type ContractMethod_S struct {
 func (m *ContractMethod_S) Serialize(writer *io.BinWriter) {
 	writer.WriteString(m.Name)
 
-	var vmType vm.VMType
-	writer.WriteB(byte(vmType.FromString(m.ReturnType)))
+	// unknown return types are written as None
+	returnType, _ := vm.ParseVMType(m.ReturnType)
+	writer.WriteB(byte(returnType))
 
 	writer.WriteU32LE(uint32(m.Offset))
 	writer.WriteB(byte(len(m.Parameters)))
@@ -0,0 +1,129 @@
+package event
+
+import (
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+)
+
+// Location is the block and transaction an event was emitted in, TxHash is empty for events of the block itself.
+// It's embedded into decoded events, so FromBlock can set it.
+type Location struct {
+	Height uint
+	TxHash string
+}
+
+func (l *Location) setLocation(loc Location) {
+	*l = loc
+}
+
+// located is a pointer to a decoded event embedding Location
+type located[T any] interface {
+	*T
+	setLocation(Location)
+}
+
+// Decode decodes hex-encoded data of event if its kind is one of kinds, false is returned for events of other kinds.
+// decode reads data from br and reports errors through br.Err.
+func Decode[T any](e resp.EventResult, decode func(kind EventKind, br *io.BinReader) T, kinds ...EventKind) (T, bool, error) {
+	var zero T
+	var kind EventKind
+	kind.SetString(e.Kind)
+	if !slices.Contains(kinds, kind) {
+		return zero, false, nil
+	}
+
+	data, err := hex.DecodeString(e.Data)
+	if err != nil {
+		return zero, false, fmt.Errorf("invalid %s event data: %w", e.Kind, err)
+	}
+
+	br := io.NewBinReaderFromBuf(data)
+	v := decode(kind, br)
+	if br.Err != nil {
+		return zero, false, fmt.Errorf("invalid %s event data: %w", e.Kind, br.Err)
+	}
+
+	return v, true, nil
+}
+
+// FromBlock returns events of successful block transactions and of the block itself decoded with decode, in order.
+// Events of failed transactions are skipped, their changes are reverted.
+func FromBlock[T any, P located[T]](block resp.BlockResult, decode func(resp.EventResult) (T, bool, error)) ([]T, error) {
+	events := []T{}
+	add := func(results []resp.EventResult, loc Location) error {
+		for _, r := range results {
+			e, ok, err := decode(r)
+			if err != nil {
+				return err
+			}
+			if ok {
+				P(&e).setLocation(loc)
+				events = append(events, e)
+			}
+		}
+		return nil
+	}
+
+	for _, tx := range block.Txs {
+		if !tx.StateIsSuccess() {
+			continue
+		}
+		if err := add(tx.Events, Location{Height: block.Height, TxHash: tx.Hash}); err != nil {
+			return nil, fmt.Errorf("transaction %s: %w", tx.Hash, err)
+		}
+	}
+
+	if err := add(block.Events, Location{Height: block.Height}); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// BlockClient is a subset of RPC methods used to read blocks, it's implemented by rpc.PhantasmaRPC
+type BlockClient interface {
+	GetBlockByHeight(chain string, height string) (resp.BlockResult, error)
+}
+
+// ForEachBlock calls fn for blocks of chain from first to last height, both inclusive, in order
+func ForEachBlock(client BlockClient, chain string, first, last uint64, fn func(resp.BlockResult) error) error {
+	if first > last {
+		return nil
+	}
+
+	for height := first; ; height++ {
+		block, err := client.GetBlockByHeight(chain, strconv.FormatUint(height, 10))
+		if err != nil {
+			return fmt.Errorf("cannot get block %d: %w", height, err)
+		}
+
+		if err := fn(block); err != nil {
+			return fmt.Errorf("block %d: %w", height, err)
+		}
+
+		// last can be the maximum height, so the loop can't check height <= last
+		if height == last {
+			return nil
+		}
+	}
+}
+
+// FromBlocks returns events of blocks from first to last height, both inclusive, see FromBlock
+func FromBlocks[T any, P located[T]](client BlockClient, chain string, first, last uint64, decode func(resp.EventResult) (T, bool, error)) ([]T, error) {
+	events := []T{}
+	err := ForEachBlock(client, chain, first, last, func(block resp.BlockResult) error {
+		e, err := FromBlock[T, P](block, decode)
+		events = append(events, e...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
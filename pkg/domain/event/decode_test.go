@@ -0,0 +1,117 @@
+package event_test
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event/eventtest"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type subject struct {
+	Kind    event.EventKind
+	Subject string
+
+	event.Location
+}
+
+func decodeSubject(e resp.EventResult) (subject, bool, error) {
+	return event.Decode(e, func(kind event.EventKind, br *io.BinReader) subject {
+		return subject{Kind: kind, Subject: br.ReadString()}
+	}, event.PollCreated, event.PollClosed)
+}
+
+type blockClient map[uint64]resp.BlockResult
+
+func (c blockClient) GetBlockByHeight(chain string, height string) (resp.BlockResult, error) {
+	h, err := strconv.ParseUint(height, 10, 64)
+	if err != nil {
+		return resp.BlockResult{}, err
+	}
+	b, ok := c[h]
+	if !ok {
+		return resp.BlockResult{}, errors.New("block not found")
+	}
+	return b, nil
+}
+
+func TestDecode(t *testing.T) {
+	s, ok, err := decodeSubject(eventtest.StringEvent(event.PollCreated, "", "elections"))
+	require.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, subject{Kind: event.PollCreated, Subject: "elections"}, s)
+
+	_, ok, err = decodeSubject(resp.EventResult{Kind: event.PollVote.String(), Data: "zz"})
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	_, _, err = decodeSubject(resp.EventResult{Kind: event.PollClosed.String(), Data: "zz"})
+	assert.NotNil(t, err)
+
+	_, _, err = decodeSubject(resp.EventResult{Kind: event.PollClosed.String(), Data: "05"})
+	assert.NotNil(t, err, "string is truncated")
+}
+
+func TestFromBlock(t *testing.T) {
+	subjects, err := event.FromBlock(resp.BlockResult{Height: 3, Txs: []resp.TransactionResult{
+		{Hash: "A", State: "Halt", Events: []resp.EventResult{
+			eventtest.StringEvent(event.PollCreated, "", "elections"),
+			{Kind: event.TokenSend.String(), Data: "00"},
+		}},
+		{Hash: "B", State: "Fault", Events: []resp.EventResult{
+			eventtest.StringEvent(event.PollClosed, "", "elections"),
+		}},
+	}, Events: []resp.EventResult{
+		eventtest.StringEvent(event.PollClosed, "", "elections"),
+	}}, decodeSubject)
+	require.Nil(t, err)
+	assert.Equal(t, []subject{
+		{Kind: event.PollCreated, Subject: "elections", Location: event.Location{Height: 3, TxHash: "A"}},
+		{Kind: event.PollClosed, Subject: "elections", Location: event.Location{Height: 3}},
+	}, subjects)
+
+	_, err = event.FromBlock(resp.BlockResult{Txs: []resp.TransactionResult{
+		{Hash: "A", State: "Halt", Events: []resp.EventResult{{Kind: event.PollCreated.String(), Data: "zz"}}},
+	}}, decodeSubject)
+	assert.NotNil(t, err)
+}
+
+func TestFromBlocks(t *testing.T) {
+	client := blockClient{}
+	for h := uint64(1); h <= 3; h++ {
+		client[h] = resp.BlockResult{Height: uint(h), Events: []resp.EventResult{
+			eventtest.StringEvent(event.PollCreated, "", strconv.FormatUint(h, 10)),
+		}}
+	}
+
+	subjects, err := event.FromBlocks(client, "main", 2, 3, decodeSubject)
+	require.Nil(t, err)
+	require.Len(t, subjects, 2)
+	assert.Equal(t, "2", subjects[0].Subject)
+	assert.Equal(t, uint(3), subjects[1].Height)
+
+	subjects, err = event.FromBlocks(client, "main", 3, 2, decodeSubject)
+	require.Nil(t, err)
+	assert.Empty(t, subjects)
+
+	_, err = event.FromBlocks(client, "main", 3, 4, decodeSubject)
+	assert.NotNil(t, err)
+}
+
+func TestForEachBlockLastHeight(t *testing.T) {
+	client := blockClient{math.MaxUint64 - 1: {}, math.MaxUint64: {}}
+
+	var count int
+	err := event.ForEachBlock(client, "main", math.MaxUint64-1, math.MaxUint64, func(resp.BlockResult) error {
+		count++
+		return nil
+	})
+	require.Nil(t, err)
+	assert.Equal(t, 2, count)
+}
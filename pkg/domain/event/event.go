@@ -2,6 +2,7 @@ package event
 
 import (
 	"encoding/hex"
+	"fmt"
 	"math/big"
 
 	crypto "github.com/phantasma-io/phantasma-go/pkg/cryptography"
@@ -167,11 +168,45 @@ const (
 	Dutch   TypeAuction = 3
 )
 
+var typeAuctionLookup = map[TypeAuction]string{
+	Fixed:   `Fixed`,
+	Classic: `Classic`,
+	Reserve: `Reserve`,
+	Dutch:   `Dutch`,
+}
+
+func (t TypeAuction) String() string {
+	return typeAuctionLookup[t]
+}
+
+// ParseTypeAuction returns auction type by its name
+func ParseTypeAuction(s string) (TypeAuction, error) {
+	for t, name := range typeAuctionLookup {
+		if name == s {
+			return t, nil
+		}
+	}
+
+	return Fixed, fmt.Errorf("unknown auction type %q", s)
+}
+
 type OrganizationEventData struct {
 	Organization  string
 	MemberAddress crypto.Address
 }
 
+// Serialize implements ther Serializable interface
+func (d *OrganizationEventData) Serialize(writer *io.BinWriter) {
+	writer.WriteString(d.Organization)
+	d.MemberAddress.Serialize(writer)
+}
+
+// Deserialize implements ther Serializable interface
+func (d *OrganizationEventData) Deserialize(reader *io.BinReader) {
+	d.Organization = reader.ReadString()
+	d.MemberAddress.Deserialize(reader)
+}
+
 type TokenEventData struct {
 	Symbol    string
 	Value     *big.Int
@@ -233,6 +268,35 @@ type ChainValueEventData struct {
 	Value big.Int
 }
 
+// Serialize implements ther Serializable interface
+func (d *ChainValueEventData) Serialize(writer *io.BinWriter) {
+	writer.WriteString(d.Name)
+	writer.WriteBigInteger(&d.Value)
+}
+
+// Deserialize implements ther Serializable interface
+func (d *ChainValueEventData) Deserialize(reader *io.BinReader) {
+	d.Name = reader.ReadString()
+	d.Value.Set(reader.ReadBigInteger())
+}
+
+type LeaderboardEventData struct {
+	Name  string
+	Value *big.Int
+}
+
+// Serialize implements ther Serializable interface
+func (d *LeaderboardEventData) Serialize(writer *io.BinWriter) {
+	writer.WriteString(d.Name)
+	writer.WriteBigInteger(d.Value)
+}
+
+// Deserialize implements ther Serializable interface
+func (d *LeaderboardEventData) Deserialize(reader *io.BinReader) {
+	d.Name = reader.ReadString()
+	d.Value = reader.ReadBigInteger()
+}
+
 type TransactionSettleEventData struct {
 	Hash     crypto.Hash
 	Platform string
@@ -241,8 +305,22 @@ type TransactionSettleEventData struct {
 
 type GasEventData struct {
 	Address crypto.Address
-	price   big.Int
-	amount  big.Int
+	Price   *big.Int
+	Amount  *big.Int
+}
+
+// Serialize implements ther Serializable interface
+func (d *GasEventData) Serialize(writer *io.BinWriter) {
+	d.Address.Serialize(writer)
+	writer.WriteBigInteger(d.Price)
+	writer.WriteBigInteger(d.Amount)
+}
+
+// Deserialize implements ther Serializable interface
+func (d *GasEventData) Deserialize(reader *io.BinReader) {
+	d.Address.Deserialize(reader)
+	d.Price = reader.ReadBigInteger()
+	d.Amount = reader.ReadBigInteger()
 }
 
 type Event struct {
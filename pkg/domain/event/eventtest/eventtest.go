@@ -0,0 +1,51 @@
+// Package eventtest provides blocks and events for tests of packages reading chain events.
+package eventtest
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strconv"
+
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+)
+
+// Blocks is a chain of blocks starting at height 1, it implements event.BlockClient
+type Blocks []resp.BlockResult
+
+// GetBlockByHeight returns block at height, heights start from 1
+func (b Blocks) GetBlockByHeight(chain string, height string) (resp.BlockResult, error) {
+	h, err := strconv.Atoi(height)
+	if err != nil || h < 1 || h > len(b) {
+		return resp.BlockResult{}, errors.New("block not found")
+	}
+
+	return b[h-1], nil
+}
+
+// GetBlockHeight returns height of the last block
+func (b Blocks) GetBlockHeight(chainName string) (*big.Int, error) {
+	return big.NewInt(int64(len(b))), nil
+}
+
+// Address returns address of keys with private key starting with b
+func Address(b byte) cryptography.Address {
+	seed := make([]byte, 32)
+	seed[0] = b
+	return cryptography.NewPhantasmaKeys(seed).Address()
+}
+
+// Event returns event of kind emitted by address, data is serialized
+func Event(kind event.EventKind, address string, data interface{ Serialize(*io.BinWriter) }) resp.EventResult {
+	return resp.EventResult{Kind: kind.String(), Address: address, Data: hex.EncodeToString(io.Serialize(data))}
+}
+
+// StringEvent returns event of kind emitted by address with string data, like names and subjects
+func StringEvent(kind event.EventKind, address string, s string) resp.EventResult {
+	bw := io.NewBufBinWriter()
+	bw.WriteString(s)
+	return resp.EventResult{Kind: kind.String(), Address: address, Data: hex.EncodeToString(bw.Bytes())}
+}
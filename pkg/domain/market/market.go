@@ -0,0 +1,170 @@
+// Package market describes NFT listings and auctions of the market contract
+package market
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	crypto "github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/phantasma-io/phantasma-go/pkg/util"
+)
+
+// Listing holds arguments of market.ListToken call. Prices are in the smallest units of QuoteSymbol.
+type Listing struct {
+	BaseSymbol  string
+	QuoteSymbol string
+	TokenID     *big.Int
+
+	// Price is a fixed price, starting price of classic and dutch auctions or a reserve price
+	Price *big.Int
+	// EndPrice is the price dutch auction ends with
+	EndPrice *big.Int
+
+	StartDate time.Time
+	EndDate   time.Time
+	// ExtensionPeriod is the number of seconds auction is extended by when a bid is made near its end
+	ExtensionPeriod *big.Int
+	Type            event.TypeAuction
+
+	ListingFee        *big.Int
+	ListingFeeAddress crypto.Address
+}
+
+// NewFixedPrice returns a listing selling NFT for price until endDate
+func NewFixedPrice(baseSymbol, quoteSymbol string, tokenID, price *big.Int, startDate, endDate time.Time) Listing {
+	return newListing(event.Fixed, baseSymbol, quoteSymbol, tokenID, price, startDate, endDate)
+}
+
+// NewClassicAuction returns an auction starting at price, bids made within extensionPeriod seconds
+// before endDate extend the auction
+func NewClassicAuction(baseSymbol, quoteSymbol string, tokenID, price *big.Int, startDate, endDate time.Time, extensionPeriod *big.Int) Listing {
+	l := newListing(event.Classic, baseSymbol, quoteSymbol, tokenID, price, startDate, endDate)
+	l.ExtensionPeriod = extensionPeriod
+	return l
+}
+
+// NewReserveAuction returns an auction which starts when the first bid reaches reservePrice
+// and lasts for extensionPeriod seconds after the last bid
+func NewReserveAuction(baseSymbol, quoteSymbol string, tokenID, reservePrice *big.Int, extensionPeriod *big.Int) Listing {
+	l := newListing(event.Reserve, baseSymbol, quoteSymbol, tokenID, reservePrice, time.Time{}, time.Time{})
+	l.ExtensionPeriod = extensionPeriod
+	return l
+}
+
+// NewDutchAuction returns an auction with price decreasing from startPrice to endPrice between startDate and endDate
+func NewDutchAuction(baseSymbol, quoteSymbol string, tokenID, startPrice, endPrice *big.Int, startDate, endDate time.Time) Listing {
+	l := newListing(event.Dutch, baseSymbol, quoteSymbol, tokenID, startPrice, startDate, endDate)
+	l.EndPrice = endPrice
+	return l
+}
+
+func newListing(t event.TypeAuction, baseSymbol, quoteSymbol string, tokenID, price *big.Int, startDate, endDate time.Time) Listing {
+	return Listing{
+		BaseSymbol:        baseSymbol,
+		QuoteSymbol:       quoteSymbol,
+		TokenID:           tokenID,
+		Price:             price,
+		EndPrice:          big.NewInt(0),
+		StartDate:         startDate,
+		EndDate:           endDate,
+		ExtensionPeriod:   big.NewInt(0),
+		Type:              t,
+		ListingFee:        big.NewInt(0),
+		ListingFeeAddress: crypto.NullAddress(),
+	}
+}
+
+// Auction is an active market listing with decoded fields
+type Auction struct {
+	Creator     crypto.Address
+	BaseSymbol  string
+	QuoteSymbol string
+	TokenID     *big.Int
+	Type        event.TypeAuction
+
+	StartDate time.Time
+	EndDate   time.Time
+
+	// Price and EndPrice are in the smallest units of QuoteSymbol
+	Price    *big.Int
+	EndPrice *big.Int
+	// PriceDecimal and EndPriceDecimal are prices converted with QuoteSymbol decimals, like "12.5"
+	PriceDecimal    string
+	EndPriceDecimal string
+
+	ExtensionPeriod *big.Int
+	ListingFee      *big.Int
+	CurrentWinner   crypto.Address
+
+	ROM []byte
+	RAM []byte
+}
+
+// NewAuction decodes auction returned by node, decimals are the decimals of auction quote token
+func NewAuction(r resp.AuctionResult, decimals int) (Auction, error) {
+	var err error
+	a := Auction{
+		BaseSymbol:  r.BaseSymbol,
+		QuoteSymbol: r.QuoteSymbol,
+		StartDate:   time.Unix(int64(r.StartDate), 0).UTC(),
+		EndDate:     time.Unix(int64(r.EndDate), 0).UTC(),
+	}
+
+	if a.Creator, err = crypto.FromString(r.CreatorAddress); err != nil {
+		return Auction{}, fmt.Errorf("invalid creator address: %w", err)
+	}
+
+	if r.CurrentWinner != "" {
+		if a.CurrentWinner, err = crypto.FromString(r.CurrentWinner); err != nil {
+			return Auction{}, fmt.Errorf("invalid current winner address: %w", err)
+		}
+	}
+
+	if a.Type, err = event.ParseTypeAuction(r.Type); err != nil {
+		return Auction{}, err
+	}
+
+	numbers := []struct {
+		name  string
+		text  string
+		value **big.Int
+	}{
+		{"token id", r.TokenID, &a.TokenID},
+		{"price", r.Price, &a.Price},
+		{"end price", r.EndPrice, &a.EndPrice},
+		{"extension period", r.ExtensionPeriod, &a.ExtensionPeriod},
+		{"listing fee", r.ListingFee, &a.ListingFee},
+	}
+	for _, n := range numbers {
+		if *n.value, err = util.ParseBigInt(n.text); err != nil {
+			return Auction{}, fmt.Errorf("invalid %s: %w", n.name, err)
+		}
+	}
+
+	if a.ROM, err = hex.DecodeString(r.ROM); err != nil {
+		return Auction{}, fmt.Errorf("invalid rom: %w", err)
+	}
+
+	if a.RAM, err = hex.DecodeString(r.RAM); err != nil {
+		return Auction{}, fmt.Errorf("invalid ram: %w", err)
+	}
+
+	a.PriceDecimal = util.ConvertDecimals(a.Price, decimals)
+	a.EndPriceDecimal = util.ConvertDecimals(a.EndPrice, decimals)
+
+	return a, nil
+}
+
+// IsActive returns true if auction has started and hasn't ended at the given time.
+// Auctions without end date, like reserve auctions before the first bid, never end.
+func (a Auction) IsActive(now time.Time) bool {
+	if now.Before(a.StartDate) {
+		return false
+	}
+
+	return a.EndDate.Unix() == 0 || now.Before(a.EndDate)
+}
@@ -0,0 +1,63 @@
+package market_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/market"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAuction(t *testing.T) {
+	creator := cryptography.NewPhantasmaKeys(make([]byte, 32)).Address()
+
+	r := resp.AuctionResult{
+		CreatorAddress:  creator.String(),
+		StartDate:       1700000000,
+		EndDate:         1700086400,
+		BaseSymbol:      "CROWN",
+		QuoteSymbol:     "SOUL",
+		TokenID:         "123456789",
+		Price:           "1250000000",
+		EndPrice:        "0",
+		ExtensionPeriod: "600",
+		Type:            "Classic",
+		ROM:             "0102",
+		RAM:             "",
+		ListingFee:      "0",
+	}
+
+	a, err := market.NewAuction(r, 8)
+	require.Nil(t, err)
+	assert.Equal(t, creator.String(), a.Creator.String())
+	assert.Equal(t, event.Classic, a.Type)
+	assert.Equal(t, big.NewInt(123456789), a.TokenID)
+	assert.Equal(t, "12.5", a.PriceDecimal)
+	assert.Equal(t, "0", a.EndPriceDecimal)
+	assert.Equal(t, big.NewInt(600), a.ExtensionPeriod)
+	assert.Equal(t, []byte{1, 2}, a.ROM)
+	assert.True(t, a.CurrentWinner.IsNull())
+
+	assert.False(t, a.IsActive(time.Unix(1699999999, 0)))
+	assert.True(t, a.IsActive(time.Unix(1700000000, 0)))
+	assert.False(t, a.IsActive(time.Unix(1700086400, 0)))
+
+	r.EndDate = 0
+	a, err = market.NewAuction(r, 8)
+	require.Nil(t, err)
+	assert.True(t, a.IsActive(time.Unix(1800000000, 0)))
+
+	r.Type = "Lottery"
+	_, err = market.NewAuction(r, 8)
+	assert.NotNil(t, err)
+
+	r.Type = "Fixed"
+	r.Price = "12.5"
+	_, err = market.NewAuction(r, 8)
+	assert.NotNil(t, err)
+}
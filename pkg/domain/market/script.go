@@ -0,0 +1,46 @@
+package market
+
+import (
+	"math/big"
+	"time"
+
+	crypto "github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+)
+
+// ListToken appends listing of NFT with the listing terms to the script, unset prices, dates and fees are passed as zero
+func ListToken(sb scriptbuilder.ScriptBuilder, from crypto.Address, listing Listing) scriptbuilder.ScriptBuilder {
+	return sb.CallContract("market", "ListToken", from, listing.BaseSymbol, listing.QuoteSymbol, listing.TokenID,
+		orZero(listing.Price), orZero(listing.EndPrice), unixOrZero(listing.StartDate), unixOrZero(listing.EndDate),
+		orZero(listing.ExtensionPeriod), big.NewInt(int64(listing.Type)), orZero(listing.ListingFee), listing.ListingFeeAddress)
+}
+
+// BidToken appends a bid of price on auction of the token to the script, for fixed price listings and dutch auctions it buys the token
+func BidToken(sb scriptbuilder.ScriptBuilder, from crypto.Address, symbol string, tokenID, price, buyingFee *big.Int, buyingFeeAddress crypto.Address) scriptbuilder.ScriptBuilder {
+	return sb.CallContract("market", "BidToken", from, symbol, tokenID, price, orZero(buyingFee), buyingFeeAddress)
+}
+
+// BuyToken appends buying of NFT listed for a fixed price to the script
+func BuyToken(sb scriptbuilder.ScriptBuilder, from crypto.Address, symbol string, tokenID *big.Int) scriptbuilder.ScriptBuilder {
+	return sb.CallContract("market", "BuyToken", from, symbol, tokenID)
+}
+
+// CancelSale appends cancellation of NFT sale to the script, NFT is returned to the seller
+func CancelSale(sb scriptbuilder.ScriptBuilder, symbol string, tokenID *big.Int) scriptbuilder.ScriptBuilder {
+	return sb.CallContract("market", "CancelSale", symbol, tokenID)
+}
+
+func orZero(n *big.Int) *big.Int {
+	if n == nil {
+		return big.NewInt(0)
+	}
+	return n
+}
+
+// unixOrZero maps zero time to timestamp 0, which market treats as unset date
+func unixOrZero(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Unix(0, 0)
+	}
+	return t
+}
@@ -0,0 +1,52 @@
+package market_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/market"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarketScripts(t *testing.T) {
+	from := cryptography.NewPhantasmaKeys(make([]byte, 32)).Address()
+	tokenID := big.NewInt(42)
+	start := time.Unix(1700000000, 0)
+	end := start.Add(24 * time.Hour)
+	zero := big.NewInt(0)
+
+	listing := market.NewDutchAuction("CROWN", "SOUL", tokenID, big.NewInt(100_00000000), big.NewInt(10_00000000), start, end)
+	script := market.ListToken(scriptbuilder.BeginScript(), from, listing).EndScript()
+	expected := scriptbuilder.BeginScript().
+		CallContract("market", "ListToken", from, "CROWN", "SOUL", tokenID, big.NewInt(100_00000000), big.NewInt(10_00000000),
+			start, end, zero, big.NewInt(int64(event.Dutch)), zero, cryptography.NullAddress()).
+		EndScript()
+	assert.Equal(t, expected, script)
+
+	// unset dates are passed as timestamp 0
+	listing = market.NewReserveAuction("CROWN", "SOUL", tokenID, big.NewInt(5_00000000), big.NewInt(600))
+	script = market.ListToken(scriptbuilder.BeginScript(), from, listing).EndScript()
+	expected = scriptbuilder.BeginScript().
+		CallContract("market", "ListToken", from, "CROWN", "SOUL", tokenID, big.NewInt(5_00000000), zero,
+			time.Unix(0, 0), time.Unix(0, 0), big.NewInt(600), big.NewInt(int64(event.Reserve)), zero, cryptography.NullAddress()).
+		EndScript()
+	assert.Equal(t, expected, script)
+
+	script = market.BidToken(scriptbuilder.BeginScript(), from, "CROWN", tokenID, big.NewInt(6_00000000), nil, cryptography.NullAddress()).EndScript()
+	expected = scriptbuilder.BeginScript().
+		CallContract("market", "BidToken", from, "CROWN", tokenID, big.NewInt(6_00000000), zero, cryptography.NullAddress()).
+		EndScript()
+	assert.Equal(t, expected, script)
+
+	script = market.BuyToken(scriptbuilder.BeginScript(), from, "CROWN", tokenID).EndScript()
+	expected = scriptbuilder.BeginScript().CallContract("market", "BuyToken", from, "CROWN", tokenID).EndScript()
+	assert.Equal(t, expected, script)
+
+	script = market.CancelSale(scriptbuilder.BeginScript(), "CROWN", tokenID).EndScript()
+	expected = scriptbuilder.BeginScript().CallContract("market", "CancelSale", "CROWN", tokenID).EndScript()
+	assert.Equal(t, expected, script)
+}
@@ -0,0 +1,197 @@
+// Package nft decodes ROM and RAM of non-fungible tokens into metadata.
+//
+// ROM and RAM are raw bytes, their layout is defined by NFT project. Decoders of
+// projects are registered per token symbol, tokens without a registered codec are
+// decoded with StructCodec, which expects serialized VMObject structs.
+package nft
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+)
+
+// Metadata is a decoded content of NFT
+type Metadata struct {
+	Name        string
+	Description string
+	ImageURL    string
+	InfoURL     string
+
+	// Attributes hold the rest of decoded ROM and RAM fields, RAM fields override ROM fields with the same key
+	Attributes map[string]interface{}
+}
+
+// Codec decodes ROM and RAM of NFTs
+type Codec interface {
+	Decode(rom, ram []byte) (Metadata, error)
+}
+
+// CodecFunc is a function implementing Codec interface
+type CodecFunc func(rom, ram []byte) (Metadata, error)
+
+// Decode implements Codec interface
+func (f CodecFunc) Decode(rom, ram []byte) (Metadata, error) {
+	return f(rom, ram)
+}
+
+// Registry holds codecs of token symbols
+type Registry struct {
+	mu       sync.RWMutex
+	codecs   map[string]Codec
+	fallback Codec
+}
+
+// NewRegistry returns a registry decoding tokens without registered codec with fallback
+func NewRegistry(fallback Codec) *Registry {
+	return &Registry{codecs: make(map[string]Codec), fallback: fallback}
+}
+
+// Register sets codec of the token symbol, replacing a previously registered one
+func (r *Registry) Register(symbol string, codec Codec) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.codecs[symbol] = codec
+}
+
+// Codec returns codec registered for the symbol or the fallback codec
+func (r *Registry) Codec(symbol string) Codec {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if c, ok := r.codecs[symbol]; ok {
+		return c
+	}
+
+	return r.fallback
+}
+
+// Decode decodes ROM and RAM of NFT with the codec of its symbol
+func (r *Registry) Decode(symbol string, rom, ram []byte) (Metadata, error) {
+	m, err := r.Codec(symbol).Decode(rom, ram)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("cannot decode %s: %w", symbol, err)
+	}
+
+	return m, nil
+}
+
+// DecodeTokenData decodes hex encoded ROM and RAM returned by getNFT
+func (r *Registry) DecodeTokenData(symbol string, data resp.TokenDataResult) (Metadata, error) {
+	rom, err := hex.DecodeString(data.ROM)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("invalid rom: %w", err)
+	}
+
+	ram, err := hex.DecodeString(data.RAM)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("invalid ram: %w", err)
+	}
+
+	return r.Decode(symbol, rom, ram)
+}
+
+// DefaultRegistry is the registry used by package level functions
+var DefaultRegistry = NewRegistry(StructCodec{})
+
+// Register sets codec of the token symbol in DefaultRegistry
+func Register(symbol string, codec Codec) {
+	DefaultRegistry.Register(symbol, codec)
+}
+
+// Decode decodes ROM and RAM of NFT with DefaultRegistry
+func Decode(symbol string, rom, ram []byte) (Metadata, error) {
+	return DefaultRegistry.Decode(symbol, rom, ram)
+}
+
+// DecodeTokenData decodes getNFT result with DefaultRegistry
+func DecodeTokenData(symbol string, data resp.TokenDataResult) (Metadata, error) {
+	return DefaultRegistry.DecodeTokenData(symbol, data)
+}
+
+// StructCodec decodes ROM and RAM serialized as VMObject structs. Keys name, description,
+// imageURL (or image) and infoURL are matched case-insensitively and set to Metadata fields,
+// other keys become attributes. Empty ROM or RAM is skipped.
+type StructCodec struct{}
+
+// Decode implements Codec interface
+func (StructCodec) Decode(rom, ram []byte) (Metadata, error) {
+	m := Metadata{Attributes: make(map[string]interface{})}
+
+	for _, part := range []struct {
+		name string
+		data []byte
+	}{{"rom", rom}, {"ram", ram}} {
+		if len(part.data) == 0 {
+			continue
+		}
+
+		var fields map[string]interface{}
+		if err := UnmarshalStruct(part.data, &fields); err != nil {
+			return Metadata{}, fmt.Errorf("invalid %s: %w", part.name, err)
+		}
+
+		for k, v := range fields {
+			m.set(k, v)
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Metadata) set(key string, value interface{}) {
+	var field *string
+	switch strings.ToLower(key) {
+	case "name":
+		field = &m.Name
+	case "description":
+		field = &m.Description
+	case "imageurl", "image":
+		field = &m.ImageURL
+	case "infourl":
+		field = &m.InfoURL
+	}
+
+	if s, ok := value.(string); ok && field != nil {
+		*field = s
+		return
+	}
+
+	m.Attributes[key] = value
+}
+
+// UnmarshalStruct deserializes VMObject from data and unmarshals it into v with vm.Unmarshal,
+// it can be used by codecs decoding ROM or RAM into project specific Go types
+func UnmarshalStruct(data []byte, v interface{}) error {
+	br := io.NewBinReaderFromBuf(data)
+	var obj vm.VMObject
+	obj.Deserialize(br)
+	if br.Err != nil {
+		return br.Err
+	}
+
+	return vm.Unmarshal(&obj, v)
+}
+
+// MarshalStruct marshals v with vm.Marshal and serializes resulting VMObject,
+// it's the reverse of UnmarshalStruct and can be used to build ROM and RAM for minting
+func MarshalStruct(v interface{}) ([]byte, error) {
+	obj, err := vm.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	bw := io.NewBufBinWriter()
+	obj.Serialize(bw.BinWriter)
+	if bw.Err != nil {
+		return nil, bw.Err
+	}
+
+	return bw.Bytes(), nil
+}
@@ -0,0 +1,78 @@
+package nft_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/phantasma-io/phantasma-go/pkg/domain/nft"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type crownROM struct {
+	Name    string `vm:"name"`
+	Image   string `vm:"imageURL"`
+	Rarity  int64  `vm:"rarity"`
+	Element string `vm:"element"`
+}
+
+type crownRAM struct {
+	Level int64 `vm:"level"`
+}
+
+func TestStructCodec(t *testing.T) {
+	rom, err := nft.MarshalStruct(crownROM{Name: "Crown #1", Image: "https://example.com/1.png", Rarity: 3, Element: "fire"})
+	require.Nil(t, err)
+	ram, err := nft.MarshalStruct(crownRAM{Level: 7})
+	require.Nil(t, err)
+
+	registry := nft.NewRegistry(nft.StructCodec{})
+	m, err := registry.DecodeTokenData("CROWN", resp.TokenDataResult{ROM: hex.EncodeToString(rom), RAM: hex.EncodeToString(ram)})
+	require.Nil(t, err)
+	assert.Equal(t, "Crown #1", m.Name)
+	assert.Equal(t, "https://example.com/1.png", m.ImageURL)
+	assert.Equal(t, "", m.Description)
+	assert.Equal(t, big.NewInt(3), m.Attributes["rarity"])
+	assert.Equal(t, "fire", m.Attributes["element"])
+	assert.Equal(t, big.NewInt(7), m.Attributes["level"])
+
+	var decoded crownROM
+	require.Nil(t, nft.UnmarshalStruct(rom, &decoded))
+	assert.Equal(t, int64(3), decoded.Rarity)
+
+	m, err = registry.Decode("CROWN", nil, nil)
+	require.Nil(t, err)
+	assert.Empty(t, m.Attributes)
+
+	_, err = registry.Decode("CROWN", []byte{0xff, 0x01}, nil)
+	assert.NotNil(t, err)
+
+	_, err = registry.DecodeTokenData("CROWN", resp.TokenDataResult{ROM: "zz"})
+	assert.NotNil(t, err)
+}
+
+func TestRegistry(t *testing.T) {
+	errLayout := errors.New("unknown layout")
+
+	registry := nft.NewRegistry(nft.StructCodec{})
+	registry.Register("GAME", nft.CodecFunc(func(rom, ram []byte) (nft.Metadata, error) {
+		if len(rom) != 1 {
+			return nft.Metadata{}, errLayout
+		}
+		return nft.Metadata{Name: "Item", Attributes: map[string]interface{}{"kind": int(rom[0])}}, nil
+	}))
+
+	m, err := registry.Decode("GAME", []byte{5}, nil)
+	require.Nil(t, err)
+	assert.Equal(t, "Item", m.Name)
+	assert.Equal(t, 5, m.Attributes["kind"])
+
+	_, err = registry.Decode("GAME", nil, nil)
+	assert.True(t, errors.Is(err, errLayout))
+
+	_, ok := registry.Codec("OTHER").(nft.StructCodec)
+	assert.True(t, ok)
+}
@@ -0,0 +1,50 @@
+package nft
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/phantasma-io/phantasma-go/pkg/domain/contract"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/phantasma-io/phantasma-go/pkg/util"
+)
+
+// ContentFromTokenData converts getNFT result into token content, hex encoded ROM and RAM are decoded
+// and infusions are parsed. Timestamp is not returned by node and is left unset.
+func ContentFromTokenData(symbol string, data resp.TokenDataResult) (contract.TokenContent, error) {
+	c := contract.TokenContent{
+		CurrentChain: data.ChainName,
+		Creator:      data.CreatorAddress,
+		CurrentOwner: data.OwnerAddress,
+		Infusion:     make([]contract.TokenInfusion, 0, len(data.Infusion)),
+		Symbol:       symbol,
+	}
+
+	var err error
+	if c.TokenID, err = util.ParseBigInt(data.ID); err != nil {
+		return contract.TokenContent{}, fmt.Errorf("invalid token id: %w", err)
+	}
+	if c.SeriesID, err = util.ParseBigInt(data.Series); err != nil {
+		return contract.TokenContent{}, fmt.Errorf("invalid series id: %w", err)
+	}
+	if c.MintID, err = util.ParseBigInt(data.Mint); err != nil {
+		return contract.TokenContent{}, fmt.Errorf("invalid mint id: %w", err)
+	}
+
+	if c.ROM, err = hex.DecodeString(data.ROM); err != nil {
+		return contract.TokenContent{}, fmt.Errorf("invalid rom: %w", err)
+	}
+	if c.RAM, err = hex.DecodeString(data.RAM); err != nil {
+		return contract.TokenContent{}, fmt.Errorf("invalid ram: %w", err)
+	}
+
+	for _, i := range data.Infusion {
+		value, err := util.ParseBigInt(i.Value)
+		if err != nil {
+			return contract.TokenContent{}, fmt.Errorf("invalid infusion of %s: %w", i.Key, err)
+		}
+		c.Infusion = append(c.Infusion, contract.TokenInfusion{Symbol: i.Key, Value: value})
+	}
+
+	return c, nil
+}
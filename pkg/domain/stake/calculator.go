@@ -0,0 +1,194 @@
+package stake
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/phantasma-io/phantasma-go/pkg/domain/types"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/phantasma-io/phantasma-go/pkg/util"
+)
+
+const (
+	// DefaultEnergyRatioDivisor is the ratio of staked SOUL to KCAL generated per claim period
+	DefaultEnergyRatioDivisor = 500
+	// DefaultClaimPeriod is the time in seconds it takes to generate KCAL
+	DefaultClaimPeriod = 86400
+	// DefaultLockTime is the time in seconds which should pass after staking before tokens can be unstaked
+	DefaultLockTime = 86400
+	// StakingDecimals are decimals of SOUL
+	StakingDecimals = 8
+	// FuelDecimals are decimals of KCAL
+	FuelDecimals = 10
+)
+
+// DefaultMasterThreshold is the amount of SOUL an address should stake to be a SoulMaster
+var DefaultMasterThreshold = big.NewInt(50000_00000000)
+
+// Calculator computes KCAL generated by staked SOUL and stake restrictions. It should be created with
+// NewCalculator and adjusted if needed, zero value of Calculator generates no KCAL.
+type Calculator struct {
+	EnergyRatioDivisor int64
+	ClaimPeriod        uint32
+	LockTime           uint32
+	MasterThreshold    *big.Int
+}
+
+// Status is a summary of address stake at some moment
+type Status struct {
+	Staked *big.Int
+	// Unclaimed is KCAL which can be claimed, in the smallest units
+	Unclaimed *big.Int
+	// NextClaim is the time next portion of KCAL is generated, it's zero if nothing is staked
+	NextClaim types.Timestamp
+	// UnstakeTime is the time staked SOUL can be unstaked after
+	UnstakeTime  types.Timestamp
+	CanUnstake   bool
+	IsSoulMaster bool
+}
+
+// UnclaimedDecimal returns unclaimed KCAL converted with KCAL decimals
+func (s Status) UnclaimedDecimal() string {
+	return util.ConvertDecimals(s.Unclaimed, FuelDecimals)
+}
+
+// NewCalculator returns calculator using mainnet rules
+func NewCalculator() Calculator {
+	return Calculator{
+		EnergyRatioDivisor: DefaultEnergyRatioDivisor,
+		ClaimPeriod:        DefaultClaimPeriod,
+		LockTime:           DefaultLockTime,
+		MasterThreshold:    DefaultMasterThreshold,
+	}
+}
+
+// EnergyPerPeriod returns KCAL generated by staked SOUL in one claim period, both in the smallest units.
+// Zero is returned if nothing is staked or EnergyRatioDivisor is not positive.
+func (c Calculator) EnergyPerPeriod(staked *big.Int) *big.Int {
+	if staked == nil || staked.Sign() <= 0 || c.EnergyRatioDivisor <= 0 {
+		return big.NewInt(0)
+	}
+
+	fuel := new(big.Int).Mul(staked, new(big.Int).Exp(big.NewInt(10), big.NewInt(FuelDecimals-StakingDecimals), nil))
+	return fuel.Div(fuel, big.NewInt(c.EnergyRatioDivisor))
+}
+
+// Unclaimed returns KCAL generated by claims, every claim generates KCAL for each full
+// claim period passed since its claim date
+func (c Calculator) Unclaimed(claims []EnergyClaim, now types.Timestamp) *big.Int {
+	total := big.NewInt(0)
+	for _, claim := range claims {
+		if !generates(claim) {
+			continue
+		}
+
+		periods := c.periodsPassed(claim, now)
+		if periods == 0 {
+			continue
+		}
+
+		energy := c.EnergyPerPeriod(claim.StakeAmount)
+		total.Add(total, energy.Mul(energy, big.NewInt(int64(periods))))
+	}
+
+	return total
+}
+
+// NextClaim returns the earliest time after now a claim generates more KCAL, false is returned if there are no claims
+func (c Calculator) NextClaim(claims []EnergyClaim, now types.Timestamp) (types.Timestamp, bool) {
+	var next types.Timestamp
+	found := false
+
+	for _, claim := range claims {
+		if !generates(claim) {
+			continue
+		}
+
+		t := claim.ClaimDate.Value + (c.periodsPassed(claim, now)+1)*c.ClaimPeriod
+		if !found || t < next.Value {
+			next = types.Timestamp{Value: t}
+			found = true
+		}
+	}
+
+	return next, found
+}
+
+// generates returns true if claim has a positive stake and a claim date, other claims generate nothing
+func generates(claim EnergyClaim) bool {
+	return claim.StakeAmount != nil && claim.StakeAmount.Sign() > 0 && claim.ClaimDate != nil
+}
+
+func (c Calculator) periodsPassed(claim EnergyClaim, now types.Timestamp) uint32 {
+	if claim.ClaimDate == nil || now.Value <= claim.ClaimDate.Value || c.ClaimPeriod == 0 {
+		return 0
+	}
+
+	return (now.Value - claim.ClaimDate.Value) / c.ClaimPeriod
+}
+
+// UnstakeTime returns the time stake can be unstaked after
+func (c Calculator) UnstakeTime(stake EnergyStake) types.Timestamp {
+	if stake.StakeTime == nil {
+		return types.Timestamp{}
+	}
+
+	return types.Timestamp{Value: stake.StakeTime.Value + c.LockTime}
+}
+
+// CanUnstake returns true if something is staked and lock time has passed
+func (c Calculator) CanUnstake(stake EnergyStake, now types.Timestamp) bool {
+	if stake.StakeAmount == nil || stake.StakeAmount.Sign() <= 0 {
+		return false
+	}
+
+	return now.Value >= c.UnstakeTime(stake).Value
+}
+
+// IsSoulMaster returns true if staked amount reaches SoulMaster threshold
+func (c Calculator) IsSoulMaster(staked *big.Int) bool {
+	return staked != nil && c.MasterThreshold != nil && staked.Cmp(c.MasterThreshold) >= 0
+}
+
+// Status returns summary of the stake, claims are claim entries of stake contract,
+// ClaimsFromStake can be used if they are not known
+func (c Calculator) Status(stake EnergyStake, claims []EnergyClaim, now types.Timestamp) Status {
+	staked := big.NewInt(0)
+	if stake.StakeAmount != nil {
+		staked.Set(stake.StakeAmount)
+	}
+
+	next, _ := c.NextClaim(claims, now)
+
+	return Status{
+		Staked:       staked,
+		Unclaimed:    c.Unclaimed(claims, now),
+		NextClaim:    next,
+		UnstakeTime:  c.UnstakeTime(stake),
+		CanUnstake:   c.CanUnstake(stake, now),
+		IsSoulMaster: c.IsSoulMaster(staked),
+	}
+}
+
+// ClaimsFromStake returns a single claim of the whole stake made at stake time, it's exact
+// for addresses which staked once and never claimed
+func ClaimsFromStake(stake EnergyStake) []EnergyClaim {
+	if stake.StakeAmount == nil || stake.StakeAmount.Sign() <= 0 {
+		return []EnergyClaim{}
+	}
+
+	return []EnergyClaim{{StakeAmount: stake.StakeAmount, ClaimDate: stake.StakeTime, IsNew: true}}
+}
+
+// NewEnergyStake converts stake returned by getAccount
+func NewEnergyStake(r resp.StakeResult) (EnergyStake, error) {
+	amount := big.NewInt(0)
+	if r.Amount != "" {
+		var ok bool
+		if amount, ok = new(big.Int).SetString(r.Amount, 10); !ok {
+			return EnergyStake{}, fmt.Errorf("invalid stake amount %q", r.Amount)
+		}
+	}
+
+	return EnergyStake{StakeAmount: amount, StakeTime: types.NewTimestamp(uint32(r.Time))}, nil
+}
@@ -0,0 +1,106 @@
+package stake_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/phantasma-io/phantasma-go/pkg/domain/stake"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/types"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	day       = 86400
+	stakeTime = 1700000000
+)
+
+func TestEnergyPerPeriod(t *testing.T) {
+	c := stake.NewCalculator()
+	// 1000 SOUL generate 2 KCAL a day
+	assert.Equal(t, big.NewInt(2_0000000000), c.EnergyPerPeriod(big.NewInt(1000_00000000)))
+	assert.Equal(t, big.NewInt(200000), c.EnergyPerPeriod(big.NewInt(1000000)))
+}
+
+func TestStatus(t *testing.T) {
+	c := stake.NewCalculator()
+	s := stake.EnergyStake{StakeAmount: big.NewInt(1500_00000000), StakeTime: types.NewTimestamp(stakeTime)}
+	claims := []stake.EnergyClaim{
+		{StakeAmount: big.NewInt(1000_00000000), ClaimDate: types.NewTimestamp(stakeTime)},
+		{StakeAmount: big.NewInt(500_00000000), ClaimDate: types.NewTimestamp(stakeTime + day/2), IsNew: true},
+	}
+
+	status := c.Status(s, claims, types.Timestamp{Value: stakeTime + day - 1})
+	assert.Equal(t, big.NewInt(0), status.Unclaimed)
+	assert.Equal(t, uint32(stakeTime+day), status.NextClaim.Value)
+	assert.Equal(t, uint32(stakeTime+day), status.UnstakeTime.Value)
+	assert.False(t, status.CanUnstake)
+	assert.False(t, status.IsSoulMaster)
+
+	status = c.Status(s, claims, types.Timestamp{Value: stakeTime + day})
+	assert.Equal(t, big.NewInt(2_0000000000), status.Unclaimed)
+	assert.Equal(t, uint32(stakeTime+day+day/2), status.NextClaim.Value)
+	assert.True(t, status.CanUnstake)
+
+	status = c.Status(s, claims, types.Timestamp{Value: stakeTime + 3*day})
+	// 3 days of 1000 SOUL and 2 days of 500 SOUL
+	assert.Equal(t, big.NewInt(8_0000000000), status.Unclaimed)
+	assert.Equal(t, "8", status.UnclaimedDecimal())
+	assert.Equal(t, uint32(stakeTime+3*day+day/2), status.NextClaim.Value)
+}
+
+func TestSoulMaster(t *testing.T) {
+	c := stake.NewCalculator()
+	assert.False(t, c.IsSoulMaster(big.NewInt(49999_99999999)))
+	assert.True(t, c.IsSoulMaster(big.NewInt(50000_00000000)))
+	assert.False(t, c.IsSoulMaster(nil))
+}
+
+func TestEmptyStake(t *testing.T) {
+	c := stake.NewCalculator()
+	s := stake.EnergyStake{StakeAmount: big.NewInt(0), StakeTime: types.NewTimestamp(0)}
+	claims := stake.ClaimsFromStake(s)
+	assert.Empty(t, claims)
+
+	status := c.Status(s, claims, types.Timestamp{Value: stakeTime})
+	assert.Equal(t, big.NewInt(0), status.Unclaimed)
+	assert.Equal(t, uint32(0), status.NextClaim.Value)
+	assert.False(t, status.CanUnstake)
+}
+
+func TestInvalidClaims(t *testing.T) {
+	c := stake.NewCalculator()
+	claims := []stake.EnergyClaim{
+		{ClaimDate: types.NewTimestamp(stakeTime)},
+		{StakeAmount: big.NewInt(-1), ClaimDate: types.NewTimestamp(stakeTime)},
+		{StakeAmount: big.NewInt(1000_00000000)},
+	}
+	now := types.Timestamp{Value: stakeTime + 2*day}
+
+	assert.Equal(t, big.NewInt(0), c.Unclaimed(claims, now))
+	_, ok := c.NextClaim(claims, now)
+	assert.False(t, ok)
+
+	claims = append(claims, stake.EnergyClaim{StakeAmount: big.NewInt(1000_00000000), ClaimDate: types.NewTimestamp(stakeTime)})
+	assert.Equal(t, big.NewInt(4_0000000000), c.Unclaimed(claims, now))
+
+	var zero stake.Calculator
+	assert.Equal(t, big.NewInt(0), zero.EnergyPerPeriod(big.NewInt(1000_00000000)))
+	assert.Equal(t, big.NewInt(0), zero.Unclaimed(claims, now))
+	assert.False(t, zero.IsSoulMaster(big.NewInt(1)))
+}
+
+func TestNewEnergyStake(t *testing.T) {
+	s, err := stake.NewEnergyStake(resp.StakeResult{Amount: "1000000000", Time: stakeTime})
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(10_00000000), s.StakeAmount)
+	assert.Equal(t, uint32(stakeTime), s.StakeTime.Value)
+
+	claims := stake.ClaimsFromStake(s)
+	require.Len(t, claims, 1)
+	assert.Equal(t, big.NewInt(2_00000000*10), stake.NewCalculator().Unclaimed(claims, types.Timestamp{Value: stakeTime + 10*day}))
+
+	_, err = stake.NewEnergyStake(resp.StakeResult{Amount: "1.5"})
+	assert.NotNil(t, err)
+}
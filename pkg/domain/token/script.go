@@ -0,0 +1,35 @@
+package token
+
+import (
+	"math/big"
+
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/contract"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+)
+
+// MintTokenContent appends minting of NFT using SeriesID, ROM and RAM of the content to the script
+func MintTokenContent(sb scriptbuilder.ScriptBuilder, symbol string, from, to cryptography.Address, content contract.TokenContent) scriptbuilder.ScriptBuilder {
+	return sb.MintToken(symbol, from, to, content.ROM, content.RAM, content.SeriesID)
+}
+
+// CreateTokenSeries appends creation of series with SeriesID, MaxSupply, Mode, Script and ABI of the series to the script
+func CreateTokenSeries(sb scriptbuilder.ScriptBuilder, symbol string, from cryptography.Address, series contract.TokenSeries) scriptbuilder.ScriptBuilder {
+	abi := io.Serialize(&series.ABI)
+	mode := big.NewInt(int64(series.Mode))
+	return sb.CallInterop("Nexus.CreateTokenSeries", from, symbol, series.SeriesID, series.MaxSupply, mode, series.Script, abi)
+}
+
+// CreateToken appends deployment of token with its contract script and ABI to the script,
+// info should be checked with TokenInfo.Validate first
+func CreateToken(sb scriptbuilder.ScriptBuilder, info TokenInfo) scriptbuilder.ScriptBuilder {
+	maxSupply := info.MaxSupply
+	if maxSupply == nil {
+		maxSupply = big.NewInt(0)
+	}
+
+	abi := io.Serialize(&info.ABI)
+	return sb.CallInterop("Nexus.CreateToken", info.Owner, info.Symbol, info.Name, maxSupply,
+		big.NewInt(int64(info.Decimals)), big.NewInt(int64(info.Flags)), info.Script, abi)
+}
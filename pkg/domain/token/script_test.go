@@ -0,0 +1,63 @@
+package token_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/contract"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/token"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+	"github.com/stretchr/testify/assert"
+	orderedmap "github.com/wk8/go-ordered-map/v2"
+)
+
+func TestMintTokenContent(t *testing.T) {
+	from := cryptography.NewPhantasmaKeys(make([]byte, 32)).Address()
+	to := cryptography.NewPhantasmaKeys(append(make([]byte, 31), 1)).Address()
+	content := contract.TokenContent{SeriesID: big.NewInt(2), ROM: []byte{1, 2}, RAM: []byte{3}}
+
+	script := token.MintTokenContent(scriptbuilder.BeginScript(), "CROWN", from, to, content).EndScript()
+	expected := scriptbuilder.BeginScript().MintToken("CROWN", from, to, []byte{1, 2}, []byte{3}, big.NewInt(2)).EndScript()
+	assert.Equal(t, expected, script)
+}
+
+func TestCreateTokenSeries(t *testing.T) {
+	from := cryptography.NewPhantasmaKeys(make([]byte, 32)).Address()
+	series := contract.TokenSeries{
+		SeriesID:  big.NewInt(3),
+		MaxSupply: big.NewInt(1000),
+		Mode:      contract.Duplicated,
+		Script:    []byte{0x0b},
+	}
+
+	script := token.CreateTokenSeries(scriptbuilder.BeginScript(), "CROWN", from, series).EndScript()
+	expected := scriptbuilder.BeginScript().
+		CallInterop("Nexus.CreateTokenSeries", from, "CROWN", big.NewInt(3), big.NewInt(1000),
+			big.NewInt(int64(contract.Duplicated)), []byte{0x0b}, io.Serialize(&series.ABI)).
+		EndScript()
+	assert.Equal(t, expected, script)
+}
+
+func TestCreateToken(t *testing.T) {
+	owner := cryptography.NewPhantasmaKeys(make([]byte, 32)).Address()
+	methods := orderedmap.New[string, contract.ContractMethod]()
+	methods.Set("getName", contract.ContractMethod{Name: "getName", ReturnType: vm.String})
+	info := token.TokenInfo{
+		Symbol: "CROWN",
+		Name:   "Crown",
+		Owner:  owner,
+		Flags:  token.Transferable | token.Burnable,
+		Script: []byte{0x0b},
+		ABI:    contract.ContractInterface{Methods: methods},
+	}
+
+	script := token.CreateToken(scriptbuilder.BeginScript(), info).EndScript()
+	expected := scriptbuilder.BeginScript().
+		CallInterop("Nexus.CreateToken", owner, "CROWN", "Crown", big.NewInt(0), big.NewInt(0),
+			big.NewInt(int64(token.Transferable|token.Burnable)), []byte{0x0b}, io.Serialize(&info.ABI)).
+		EndScript()
+	assert.Equal(t, expected, script)
+}
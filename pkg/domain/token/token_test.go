@@ -0,0 +1,109 @@
+package token_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/contract"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/token"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	orderedmap "github.com/wk8/go-ordered-map/v2"
+)
+
+func newTokenInfo() token.TokenInfo {
+	methods := orderedmap.New[string, contract.ContractMethod]()
+	methods.Set("getName", contract.ContractMethod{Name: "getName", ReturnType: vm.String, Offset: 0})
+
+	return token.TokenInfo{
+		Symbol:    "GAME",
+		Name:      "Game token",
+		Owner:     cryptography.NewPhantasmaKeys(make([]byte, 32)).Address(),
+		Flags:     token.Transferable | token.Fungible | token.Finite | token.Divisible,
+		MaxSupply: big.NewInt(1000000_00000000),
+		Decimals:  8,
+		Script:    []byte{0x0b},
+		ABI:       contract.ContractInterface{Methods: methods, Events: []contract.ContractEvent{}},
+	}
+}
+
+func TestTokenInfoSerialization(t *testing.T) {
+	info := newTokenInfo()
+	bytes := io.Serialize(&info)
+
+	expected := "0447414d450a47616d6520746f6b656e22" +
+		"0100" + hex.EncodeToString(info.Owner.Bytes()[2:]) +
+		"0f000000" + "08000000" + "07" + "00407a10f35a00" + "010b" +
+		"10" + "0107" + "6765744e616d65" + "04" + "00000000" + "00" + "00"
+	assert.Equal(t, expected, hex.EncodeToString(bytes))
+
+	restored := io.Deserialize[*token.TokenInfo](bytes)
+	assert.Equal(t, info.Symbol, restored.Symbol)
+	assert.Equal(t, info.Flags, restored.Flags)
+	assert.Equal(t, info.MaxSupply, restored.MaxSupply)
+	assert.Equal(t, info.Decimals, restored.Decimals)
+	method, ok := restored.ABI.Methods.Get("getName")
+	require.True(t, ok)
+	assert.Equal(t, vm.String, method.ReturnType)
+}
+
+func TestValidate(t *testing.T) {
+	info := newTokenInfo()
+	assert.Nil(t, info.Validate())
+
+	nft := newTokenInfo()
+	nft.Flags = token.Transferable | token.Burnable
+	nft.Decimals = 0
+	nft.MaxSupply = nil
+	assert.Nil(t, nft.Validate())
+
+	tests := map[string]func(i *token.TokenInfo){
+		"short symbol":           func(i *token.TokenInfo) { i.Symbol = "G" },
+		"long symbol":            func(i *token.TokenInfo) { i.Symbol = "GAMETK" },
+		"lowercase symbol":       func(i *token.TokenInfo) { i.Symbol = "Game" },
+		"empty name":             func(i *token.TokenInfo) { i.Name = "" },
+		"null owner":             func(i *token.TokenInfo) { i.Owner = cryptography.NullAddress() },
+		"unknown flag":           func(i *token.TokenInfo) { i.Flags |= 1 << 12 },
+		"divisible non-fungible": func(i *token.TokenInfo) { i.Flags &^= token.Fungible },
+		"stakable non-fungible":  func(i *token.TokenInfo) { i.Flags = token.Stakable },
+		"divisible no decimals":  func(i *token.TokenInfo) { i.Decimals = 0 },
+		"decimals not divisible": func(i *token.TokenInfo) { i.Flags &^= token.Divisible },
+		"too many decimals":      func(i *token.TokenInfo) { i.Decimals = 19 },
+		"finite no max supply":   func(i *token.TokenInfo) { i.MaxSupply = big.NewInt(0) },
+		"max supply not finite":  func(i *token.TokenInfo) { i.Flags &^= token.Finite },
+		"negative max supply":    func(i *token.TokenInfo) { i.MaxSupply = big.NewInt(-1) },
+	}
+	for name, change := range tests {
+		info := newTokenInfo()
+		change(&info)
+		err := info.Validate()
+		assert.True(t, errors.Is(err, token.ErrInvalidToken), name)
+	}
+}
+
+func TestValidateSeries(t *testing.T) {
+	nft := newTokenInfo()
+	nft.Flags = token.Transferable | token.Finite
+	nft.Decimals = 0
+	nft.MaxSupply = big.NewInt(100)
+
+	series := contract.TokenSeries{SeriesID: big.NewInt(1), MaxSupply: big.NewInt(10), Mode: contract.Unique}
+	assert.Nil(t, token.ValidateSeries(nft, series))
+
+	assert.True(t, errors.Is(token.ValidateSeries(newTokenInfo(), series), token.ErrInvalidSeries))
+
+	invalid := []contract.TokenSeries{
+		{SeriesID: big.NewInt(-1), MaxSupply: big.NewInt(10), Mode: contract.Unique},
+		{SeriesID: big.NewInt(1), MaxSupply: nil, Mode: contract.Unique},
+		{SeriesID: big.NewInt(1), MaxSupply: big.NewInt(10), Mode: 0},
+		{SeriesID: big.NewInt(1), MaxSupply: big.NewInt(101), Mode: contract.Duplicated},
+	}
+	for _, s := range invalid {
+		assert.True(t, errors.Is(token.ValidateSeries(nft, s), token.ErrInvalidSeries))
+	}
+}
@@ -0,0 +1,126 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/phantasma-io/phantasma-go/pkg/domain/contract"
+)
+
+// Limits of token definitions enforced by Validate
+const (
+	MinSymbolLength = 2
+	MaxSymbolLength = 5
+	MaxDecimals     = 18
+)
+
+// allFlags is the mask of all known token flags
+const allFlags = Transferable | Fungible | Finite | Divisible | Fuel | Stakable | Fiat | Swappable | Burnable | Mintable
+
+var (
+	// ErrInvalidToken is wrapped by errors returned by TokenInfo.Validate
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrInvalidSeries is wrapped by errors returned by ValidateSeries
+	ErrInvalidSeries = errors.New("invalid token series")
+)
+
+func (tf TokenFlags) String() string {
+	result := ""
+	for _, s := range tf.ToSlice() {
+		if result != "" {
+			result += "|"
+		}
+		result += s
+	}
+	return result
+}
+
+// Validate checks that token can be created: symbol and name are well-formed,
+// flag combination is consistent and decimals and max supply agree with flags
+func (ti *TokenInfo) Validate() error {
+	if len(ti.Symbol) < MinSymbolLength || len(ti.Symbol) > MaxSymbolLength {
+		return fmt.Errorf("%w: symbol %q must be %d to %d characters long", ErrInvalidToken, ti.Symbol, MinSymbolLength, MaxSymbolLength)
+	}
+
+	for _, c := range ti.Symbol {
+		if c < 'A' || c > 'Z' {
+			return fmt.Errorf("%w: symbol %q must consist of uppercase latin letters", ErrInvalidToken, ti.Symbol)
+		}
+	}
+
+	if ti.Name == "" {
+		return fmt.Errorf("%w: name is empty", ErrInvalidToken)
+	}
+
+	if ti.Owner.IsNull() {
+		return fmt.Errorf("%w: owner is not set", ErrInvalidToken)
+	}
+
+	if ti.Flags&^allFlags != 0 {
+		return fmt.Errorf("%w: unknown flags 0x%x", ErrInvalidToken, uint(ti.Flags&^allFlags))
+	}
+
+	fungible := ti.Flags.hasFlag(Fungible)
+	for _, f := range []TokenFlags{Divisible, Fuel, Stakable, Fiat} {
+		if ti.Flags.hasFlag(f) && !fungible {
+			return fmt.Errorf("%w: %s flag requires Fungible", ErrInvalidToken, f)
+		}
+	}
+
+	if ti.Decimals < 0 || ti.Decimals > MaxDecimals {
+		return fmt.Errorf("%w: decimals must be between 0 and %d", ErrInvalidToken, MaxDecimals)
+	}
+
+	if ti.Flags.hasFlag(Divisible) && ti.Decimals == 0 {
+		return fmt.Errorf("%w: divisible token must have decimals", ErrInvalidToken)
+	}
+
+	if !ti.Flags.hasFlag(Divisible) && ti.Decimals != 0 {
+		return fmt.Errorf("%w: token with %d decimals must be Divisible", ErrInvalidToken, ti.Decimals)
+	}
+
+	maxSupply := ti.MaxSupply
+	if maxSupply == nil {
+		maxSupply = big.NewInt(0)
+	}
+
+	if maxSupply.Sign() < 0 {
+		return fmt.Errorf("%w: max supply is negative", ErrInvalidToken)
+	}
+
+	if ti.Flags.hasFlag(Finite) && maxSupply.Sign() == 0 {
+		return fmt.Errorf("%w: finite token must have max supply", ErrInvalidToken)
+	}
+
+	if !ti.Flags.hasFlag(Finite) && maxSupply.Sign() != 0 {
+		return fmt.Errorf("%w: token with max supply must be Finite", ErrInvalidToken)
+	}
+
+	return nil
+}
+
+// ValidateSeries checks that series can be created for the token
+func ValidateSeries(info TokenInfo, series contract.TokenSeries) error {
+	if info.Flags.hasFlag(Fungible) {
+		return fmt.Errorf("%w: %s is fungible", ErrInvalidSeries, info.Symbol)
+	}
+
+	if series.SeriesID == nil || series.SeriesID.Sign() < 0 {
+		return fmt.Errorf("%w: series id must be a non-negative number", ErrInvalidSeries)
+	}
+
+	if series.MaxSupply == nil || series.MaxSupply.Sign() < 0 {
+		return fmt.Errorf("%w: max supply must be a non-negative number", ErrInvalidSeries)
+	}
+
+	if series.Mode != contract.Unique && series.Mode != contract.Duplicated {
+		return fmt.Errorf("%w: unknown mode %d", ErrInvalidSeries, series.Mode)
+	}
+
+	if info.MaxSupply != nil && info.MaxSupply.Sign() > 0 && series.MaxSupply.Cmp(info.MaxSupply) > 0 {
+		return fmt.Errorf("%w: series max supply exceeds token max supply %s", ErrInvalidSeries, info.MaxSupply)
+	}
+
+	return nil
+}
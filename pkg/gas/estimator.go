@@ -0,0 +1,207 @@
+// Package gas estimates gas limit and size of transaction scripts before they are broadcasted.
+//
+// Estimator runs a probe script, which is the transaction script with a generous gas limit,
+// measures gas it consumed and builds the final script with AllowGas limit derived from
+// the measurement plus a safety margin.
+package gas
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	crypto "github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+)
+
+const (
+	// DefaultMaxScriptSize is the default limit of a transaction script size in bytes
+	DefaultMaxScriptSize = 32 * 1024
+	// DefaultMargin is the default safety margin, in percent, added to measured gas
+	DefaultMargin = 20
+)
+
+var (
+	// DefaultMinGasLimit is the default lower bound of estimated gas limit
+	DefaultMinGasLimit = big.NewInt(10000)
+	// DefaultProbeGasLimit is the default gas limit of probe scripts
+	DefaultProbeGasLimit = big.NewInt(100000)
+
+	// ErrScriptTooLarge is returned when script exceeds maximum size
+	ErrScriptTooLarge = errors.New("script is too large")
+	// ErrGasNotReported is returned when script invocation result has no GasPayment event
+	ErrGasNotReported = errors.New("gas usage is not reported")
+)
+
+// Meter executes a script without committing it and returns gas it used
+type Meter interface {
+	MeasureGas(chain string, script []byte) (*big.Int, error)
+}
+
+// Invoker executes read-only scripts, it's implemented by rpc.PhantasmaRPC
+type Invoker interface {
+	InvokeRawScript(chain, script string) (resp.ScriptResult, error)
+}
+
+// NodeMeter measures gas by invoking scripts on a node, gas used is taken from GasPayment event
+type NodeMeter struct {
+	Invoker Invoker
+}
+
+// MeasureGas implements Meter interface
+func (m NodeMeter) MeasureGas(chain string, script []byte) (*big.Int, error) {
+	result, err := m.Invoker.InvokeRawScript(chain, hex.EncodeToString(script))
+	if err != nil {
+		return nil, err
+	}
+
+	return GasUsedFromEvents(result.Events)
+}
+
+// GasUsedFromEvents returns amount of gas paid with GasPayment events.
+// GasEscrow events hold the gas limit reserved by AllowGas rather than gas used, so they are not
+// counted. Escrow without payment means script didn't reach SpendGas and its gas usage is unknown.
+func GasUsedFromEvents(events []resp.EventResult) (*big.Int, error) {
+	used := big.NewInt(0)
+	found := false
+	escrowed := false
+
+	for _, e := range events {
+		if e.Kind == event.GasEscrow.String() {
+			escrowed = true
+			continue
+		}
+
+		if e.Kind != event.GasPayment.String() {
+			continue
+		}
+
+		data, err := hex.DecodeString(e.Data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gas event data: %w", err)
+		}
+
+		br := io.NewBinReaderFromBuf(data)
+		var gas event.GasEventData
+		gas.Deserialize(br)
+		if br.Err != nil {
+			return nil, fmt.Errorf("invalid gas event data: %w", br.Err)
+		}
+
+		used.Add(used, gas.Amount)
+		found = true
+	}
+
+	if !found && escrowed {
+		return nil, fmt.Errorf("%w: gas was escrowed but not paid, script should call SpendGas", ErrGasNotReported)
+	}
+
+	if !found {
+		return nil, ErrGasNotReported
+	}
+
+	return used, nil
+}
+
+// CheckScriptSize returns an error if script is larger than maxSize bytes
+func CheckScriptSize(script []byte, maxSize int) error {
+	if len(script) > maxSize {
+		return fmt.Errorf("%w: %d bytes, limit is %d", ErrScriptTooLarge, len(script), maxSize)
+	}
+
+	return nil
+}
+
+// Estimate is a result of gas estimation
+type Estimate struct {
+	// Script is the transaction script with AllowGas using estimated GasLimit
+	Script []byte
+	// ScriptSize is the size of Script in bytes
+	ScriptSize int
+	// GasUsed is gas consumed by the probe script
+	GasUsed *big.Int
+	// GasLimit is the recommended gas limit
+	GasLimit *big.Int
+	// MaxFee is the maximum fee paid with the recommended gas limit, GasPrice * GasLimit
+	MaxFee *big.Int
+}
+
+// Estimator estimates gas limit of transaction scripts
+type Estimator struct {
+	Meter Meter
+	Chain string
+
+	// Margin is the percent added on top of measured gas
+	Margin uint
+	// MinGasLimit is the lower bound of estimated gas limit
+	MinGasLimit *big.Int
+	// ProbeGasLimit is the gas limit used in probe scripts, payer needs enough KCAL to escrow it
+	ProbeGasLimit *big.Int
+	// MaxScriptSize is the limit of the final script size
+	MaxScriptSize int
+}
+
+// NewEstimator returns an estimator using default settings for the main chain
+func NewEstimator(meter Meter) *Estimator {
+	return &Estimator{
+		Meter:         meter,
+		Chain:         "main",
+		Margin:        DefaultMargin,
+		MinGasLimit:   DefaultMinGasLimit,
+		ProbeGasLimit: DefaultProbeGasLimit,
+		MaxScriptSize: DefaultMaxScriptSize,
+	}
+}
+
+// BuildFunc appends transaction calls to a script, it's called for probe and final scripts
+type BuildFunc func(sb scriptbuilder.ScriptBuilder) scriptbuilder.ScriptBuilder
+
+// Estimate measures gas used by calls appended by build, paid by from with the given gas price,
+// and returns the final script surrounded by AllowGas and SpendGas calls
+func (e *Estimator) Estimate(from crypto.Address, gasPrice *big.Int, build BuildFunc) (Estimate, error) {
+	probe := buildScript(from, gasPrice, e.ProbeGasLimit, build)
+	if err := CheckScriptSize(probe, e.MaxScriptSize); err != nil {
+		return Estimate{}, err
+	}
+
+	used, err := e.Meter.MeasureGas(e.Chain, probe)
+	if err != nil {
+		return Estimate{}, fmt.Errorf("cannot measure gas: %w", err)
+	}
+
+	limit := e.GasLimit(used)
+	script := buildScript(from, gasPrice, limit, build)
+	if err := CheckScriptSize(script, e.MaxScriptSize); err != nil {
+		return Estimate{}, err
+	}
+
+	return Estimate{
+		Script:     script,
+		ScriptSize: len(script),
+		GasUsed:    used,
+		GasLimit:   limit,
+		MaxFee:     new(big.Int).Mul(gasPrice, limit),
+	}, nil
+}
+
+// GasLimit returns recommended gas limit for the measured gas usage
+func (e *Estimator) GasLimit(used *big.Int) *big.Int {
+	limit := new(big.Int).Mul(used, big.NewInt(int64(100+e.Margin)))
+	limit.Add(limit, big.NewInt(99))
+	limit.Div(limit, big.NewInt(100))
+
+	if e.MinGasLimit != nil && limit.Cmp(e.MinGasLimit) < 0 {
+		limit.Set(e.MinGasLimit)
+	}
+
+	return limit
+}
+
+func buildScript(from crypto.Address, gasPrice, gasLimit *big.Int, build BuildFunc) []byte {
+	sb := scriptbuilder.BeginScript().AllowGas(from, crypto.NullAddress(), gasPrice, gasLimit)
+	return build(sb).SpendGas(from).EndScript()
+}
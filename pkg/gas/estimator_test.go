@@ -0,0 +1,125 @@
+package gas_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/phantasma-io/phantasma-go/pkg/blockchain"
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event"
+	"github.com/phantasma-io/phantasma-go/pkg/gas"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/phantasma-io/phantasma-go/pkg/simulator"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var gasPrice = big.NewInt(100000)
+
+func TestEstimate(t *testing.T) {
+	sender := cryptography.NewPhantasmaKeys(append(make([]byte, 31), 1))
+	receiver := cryptography.NewPhantasmaKeys(append(make([]byte, 31), 2))
+
+	nexus := simulator.NewNexus()
+	require.Nil(t, nexus.Mint("SOUL", sender.Address(), big.NewInt(1000_00000000)))
+	require.Nil(t, nexus.Mint("KCAL", sender.Address(), big.NewInt(100_0000000000)))
+
+	estimator := gas.NewEstimator(nexus)
+	estimator.MinGasLimit = big.NewInt(1)
+
+	estimate, err := estimator.Estimate(sender.Address(), gasPrice, func(sb scriptbuilder.ScriptBuilder) scriptbuilder.ScriptBuilder {
+		return sb.TransferTokens("SOUL", sender.Address(), receiver.Address(), big.NewInt(10_00000000))
+	})
+	require.Nil(t, err)
+
+	assert.Equal(t, len(estimate.Script), estimate.ScriptSize)
+	assert.True(t, estimate.GasUsed.Sign() > 0)
+	assert.True(t, estimate.GasLimit.Cmp(estimate.GasUsed) > 0)
+	assert.Equal(t, new(big.Int).Mul(gasPrice, estimate.GasLimit), estimate.MaxFee)
+
+	tx := blockchain.NewTransaction(nexus.Name, simulator.ChainName, estimate.Script, nexus.Time()+60, nil)
+	tx.Sign(sender)
+	record, err := nexus.SendTransaction(tx)
+	require.Nil(t, err)
+	require.Equal(t, vm.Halt, record.State, record.Err)
+	assert.Equal(t, estimate.GasUsed.Uint64(), record.GasUsed)
+	assert.Equal(t, big.NewInt(10_00000000), nexus.BalanceOf("SOUL", receiver.Address()))
+
+	estimator.MaxScriptSize = 16
+	_, err = estimator.Estimate(sender.Address(), gasPrice, func(sb scriptbuilder.ScriptBuilder) scriptbuilder.ScriptBuilder {
+		return sb
+	})
+	assert.True(t, errors.Is(err, gas.ErrScriptTooLarge))
+}
+
+func TestGasLimit(t *testing.T) {
+	estimator := gas.NewEstimator(nil)
+	assert.Equal(t, gas.DefaultMinGasLimit, estimator.GasLimit(big.NewInt(100)))
+	assert.Equal(t, big.NewInt(120000), estimator.GasLimit(big.NewInt(100000)))
+	assert.Equal(t, big.NewInt(120002), estimator.GasLimit(big.NewInt(100001)))
+
+	estimator.Margin = 0
+	estimator.MinGasLimit = nil
+	assert.Equal(t, big.NewInt(100), estimator.GasLimit(big.NewInt(100)))
+}
+
+func TestCheckScriptSize(t *testing.T) {
+	assert.Nil(t, gas.CheckScriptSize(make([]byte, 10), 10))
+	assert.True(t, errors.Is(gas.CheckScriptSize(make([]byte, 11), 10), gas.ErrScriptTooLarge))
+}
+
+type invoker struct {
+	events []resp.EventResult
+}
+
+func (i invoker) InvokeRawScript(chain, script string) (resp.ScriptResult, error) {
+	return resp.ScriptResult{Events: i.events}, nil
+}
+
+func TestNodeMeter(t *testing.T) {
+	payer := cryptography.NewPhantasmaKeys(make([]byte, 32)).Address()
+	data := gasEventData(payer, big.NewInt(1234))
+
+	meter := gas.NodeMeter{Invoker: invoker{events: []resp.EventResult{
+		{Kind: event.GasEscrow.String(), Data: data},
+		{Kind: event.GasPayment.String(), Data: data},
+	}}}
+	used, err := meter.MeasureGas("main", []byte{0x0b})
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(1234), used)
+
+	meter = gas.NodeMeter{Invoker: invoker{}}
+	_, err = meter.MeasureGas("main", []byte{0x0b})
+	assert.True(t, errors.Is(err, gas.ErrGasNotReported))
+}
+
+func TestGasUsedFromEvents(t *testing.T) {
+	payer := cryptography.NewPhantasmaKeys(make([]byte, 32)).Address()
+
+	// escrowed limit is not gas used
+	used, err := gas.GasUsedFromEvents([]resp.EventResult{
+		{Kind: event.GasEscrow.String(), Data: gasEventData(payer, big.NewInt(100000))},
+		{Kind: event.GasPayment.String(), Data: gasEventData(payer, big.NewInt(1234))},
+	})
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(1234), used)
+
+	_, err = gas.GasUsedFromEvents([]resp.EventResult{
+		{Kind: event.GasEscrow.String(), Data: gasEventData(payer, big.NewInt(100000))},
+	})
+	assert.True(t, errors.Is(err, gas.ErrGasNotReported))
+	assert.ErrorContains(t, err, "escrowed but not paid")
+
+	_, err = gas.GasUsedFromEvents([]resp.EventResult{{Kind: event.GasPayment.String(), Data: "zz"}})
+	assert.ErrorContains(t, err, "invalid gas event data")
+}
+
+func gasEventData(address cryptography.Address, amount *big.Int) string {
+	data := event.GasEventData{Address: address, Price: gasPrice, Amount: amount}
+	return hex.EncodeToString(io.Serialize(&data))
+}
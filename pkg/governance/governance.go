@@ -0,0 +1,211 @@
+// Package governance reads chain values of the nexus as typed numbers.
+//
+// Governance values are numbers stored by the governance contract under names like
+// "validator.count". Nexus reports all of them with getNexus, changes are announced
+// with ValueCreate and ValueUpdate events.
+package governance
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+)
+
+// Names of well-known governance values
+const (
+	ProtocolVersion       = "nexus.protocol.version"
+	ContractDeployFee     = "nexus.contract.cost"
+	TokenDeployFee        = "nexus.token.cost"
+	OrganizationDeployFee = "nexus.organization.cost"
+
+	ValidatorCount        = "validator.count"
+	ValidatorRotationTime = "validator.rotation.time"
+
+	MasterStakeThreshold = "stake.master.threshold"
+	VotingStakeThreshold = "stake.vote.threshold"
+	StakeBonusPercent    = "stake.bonus.percent"
+	StakeMaxBonusPercent = "stake.bonus.max"
+
+	StorageKilobytesPerStake = "storage.stake.kb"
+	FreeStoragePerContract   = "storage.contract.kb"
+
+	PollVoteLimit  = "poll.vote.limit"
+	PollMaxEntries = "poll.max.entries"
+	PollMaxLength  = "poll.max.length"
+)
+
+// knownNames are names of values mapped to Config fields
+var knownNames = []string{
+	ProtocolVersion, ContractDeployFee, TokenDeployFee, OrganizationDeployFee,
+	ValidatorCount, ValidatorRotationTime,
+	MasterStakeThreshold, VotingStakeThreshold, StakeBonusPercent, StakeMaxBonusPercent,
+	StorageKilobytesPerStake, FreeStoragePerContract,
+	PollVoteLimit, PollMaxEntries, PollMaxLength,
+}
+
+// Values are governance values by name
+type Values map[string]*big.Int
+
+// ParseValues converts governance values returned by getNexus
+func ParseValues(governance []resp.GovernanceResult) (Values, error) {
+	values := make(Values, len(governance))
+	for _, g := range governance {
+		v, ok := new(big.Int).SetString(g.Value, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid value %q of %s", g.Value, g.Name)
+		}
+		values[g.Name] = v
+	}
+
+	return values, nil
+}
+
+// Get returns value by name, false is returned if there is no such value
+func (v Values) Get(name string) (*big.Int, bool) {
+	value, ok := v[name]
+	if !ok {
+		return nil, false
+	}
+
+	return new(big.Int).Set(value), true
+}
+
+// Int64 returns value by name or zero if there is no such value
+func (v Values) Int64(name string) int64 {
+	if value, ok := v[name]; ok {
+		return value.Int64()
+	}
+
+	return 0
+}
+
+// Number returns value by name or zero if there is no such value
+func (v Values) Number(name string) *big.Int {
+	if value, ok := v.Get(name); ok {
+		return value
+	}
+
+	return big.NewInt(0)
+}
+
+// Seconds returns value by name as duration, values like ValidatorRotationTime are in seconds
+func (v Values) Seconds(name string) time.Duration {
+	return time.Duration(v.Int64(name)) * time.Second
+}
+
+// Names returns names of all values, sorted
+func (v Values) Names() []string {
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Apply sets value changed by a ValueCreate or ValueUpdate event
+func (v Values) Apply(c Change) {
+	v[c.Name] = new(big.Int).Set(c.Value)
+}
+
+// Config is a snapshot of the nexus configuration, values missing on chain are zero
+type Config struct {
+	Nexus    string
+	Protocol uint
+
+	ProtocolVersion       int64
+	ContractDeployFee     *big.Int
+	TokenDeployFee        *big.Int
+	OrganizationDeployFee *big.Int
+
+	ValidatorCount        int64
+	ValidatorRotationTime time.Duration
+
+	MasterStakeThreshold *big.Int
+	VotingStakeThreshold *big.Int
+	StakeBonusPercent    int64
+	StakeMaxBonusPercent int64
+
+	StorageKilobytesPerStake int64
+	FreeStoragePerContract   int64
+
+	PollVoteLimit  int64
+	PollMaxEntries int64
+	PollMaxLength  time.Duration
+
+	// Values hold all governance values, including the ones without Config fields
+	Values Values
+}
+
+// NewConfig returns config with fields set from values
+func NewConfig(values Values) Config {
+	return Config{
+		ProtocolVersion:       values.Int64(ProtocolVersion),
+		ContractDeployFee:     values.Number(ContractDeployFee),
+		TokenDeployFee:        values.Number(TokenDeployFee),
+		OrganizationDeployFee: values.Number(OrganizationDeployFee),
+
+		ValidatorCount:        values.Int64(ValidatorCount),
+		ValidatorRotationTime: values.Seconds(ValidatorRotationTime),
+
+		MasterStakeThreshold: values.Number(MasterStakeThreshold),
+		VotingStakeThreshold: values.Number(VotingStakeThreshold),
+		StakeBonusPercent:    values.Int64(StakeBonusPercent),
+		StakeMaxBonusPercent: values.Int64(StakeMaxBonusPercent),
+
+		StorageKilobytesPerStake: values.Int64(StorageKilobytesPerStake),
+		FreeStoragePerContract:   values.Int64(FreeStoragePerContract),
+
+		PollVoteLimit:  values.Int64(PollVoteLimit),
+		PollMaxEntries: values.Int64(PollMaxEntries),
+		PollMaxLength:  values.Seconds(PollMaxLength),
+
+		Values: values,
+	}
+}
+
+// NewConfigFromNexus returns config of nexus returned by getNexus
+func NewConfigFromNexus(nexus resp.NexusResult) (Config, error) {
+	values, err := ParseValues(nexus.Governance)
+	if err != nil {
+		return Config{}, err
+	}
+
+	c := NewConfig(values)
+	c.Nexus = nexus.Name
+	c.Protocol = nexus.Protocol
+	return c, nil
+}
+
+// Missing returns names of well-known values which are not set on chain
+func (c Config) Missing() []string {
+	missing := []string{}
+	for _, name := range knownNames {
+		if _, ok := c.Values[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	return missing
+}
+
+// Client is a subset of RPC methods used by governance, it's implemented by rpc.PhantasmaRPC
+type Client interface {
+	GetNexus(extended bool) (resp.NexusResult, error)
+	GetBlockHeight(chainName string) (*big.Int, error)
+	GetBlockByHeight(chain string, height string) (resp.BlockResult, error)
+}
+
+// Fetch returns current configuration of the nexus
+func Fetch(client Client) (Config, error) {
+	nexus, err := client.GetNexus(false)
+	if err != nil {
+		return Config{}, err
+	}
+
+	return NewConfigFromNexus(nexus)
+}
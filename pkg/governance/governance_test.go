@@ -0,0 +1,122 @@
+package governance_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event/eventtest"
+	"github.com/phantasma-io/phantasma-go/pkg/governance"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	nexus resp.NexusResult
+	eventtest.Blocks
+}
+
+func (c *fakeClient) GetNexus(extended bool) (resp.NexusResult, error) {
+	return c.nexus, nil
+}
+
+func valueEvent(kind event.EventKind, name string, value int64) resp.EventResult {
+	d := event.ChainValueEventData{Name: name}
+	d.Value.SetInt64(value)
+	return eventtest.Event(kind, "governance", &d)
+}
+
+func TestFetch(t *testing.T) {
+	client := &fakeClient{nexus: resp.NexusResult{
+		Name:     "mainnet",
+		Protocol: 16,
+		Governance: []resp.GovernanceResult{
+			{Name: governance.ValidatorCount, Value: "5"},
+			{Name: governance.ValidatorRotationTime, Value: "120"},
+			{Name: governance.MasterStakeThreshold, Value: "5000000000000"},
+			{Name: governance.TokenDeployFee, Value: "10000000000000"},
+			{Name: "custom.value", Value: "7"},
+		},
+	}}
+
+	config, err := governance.Fetch(client)
+	require.Nil(t, err)
+	assert.Equal(t, "mainnet", config.Nexus)
+	assert.Equal(t, uint(16), config.Protocol)
+	assert.Equal(t, int64(5), config.ValidatorCount)
+	assert.Equal(t, 2*time.Minute, config.ValidatorRotationTime)
+	assert.Equal(t, big.NewInt(50000_00000000), config.MasterStakeThreshold)
+	assert.Equal(t, big.NewInt(1000_0000000000), config.TokenDeployFee)
+	assert.Equal(t, big.NewInt(0), config.ContractDeployFee)
+	assert.Equal(t, int64(7), config.Values.Int64("custom.value"))
+	assert.Contains(t, config.Missing(), governance.ContractDeployFee)
+	assert.NotContains(t, config.Missing(), governance.ValidatorCount)
+
+	_, ok := config.Values.Get("unknown")
+	assert.False(t, ok)
+
+	client.nexus.Governance = append(client.nexus.Governance, resp.GovernanceResult{Name: "broken", Value: "1.5"})
+	_, err = governance.Fetch(client)
+	assert.NotNil(t, err)
+}
+
+func TestChainValueEventData(t *testing.T) {
+	e := valueEvent(event.ValueUpdate, governance.ValidatorCount, 7)
+
+	data, err := hex.DecodeString(e.Data)
+	require.Nil(t, err)
+	d := io.Deserialize[*event.ChainValueEventData](data)
+	assert.Equal(t, governance.ValidatorCount, d.Name)
+	assert.Equal(t, big.NewInt(7), &d.Value)
+}
+
+func TestWatcher(t *testing.T) {
+	client := &fakeClient{
+		nexus: resp.NexusResult{Governance: []resp.GovernanceResult{{Name: governance.ValidatorCount, Value: "5"}}},
+		Blocks: eventtest.Blocks{
+			{Height: 1, Txs: []resp.TransactionResult{{Hash: "A", State: "Halt", Events: []resp.EventResult{
+				valueEvent(event.ValueUpdate, governance.ValidatorCount, 6),
+			}}}},
+		},
+	}
+
+	config, err := governance.Fetch(client)
+	require.Nil(t, err)
+
+	w := governance.NewWatcher(client, 0)
+	changes, err := w.Poll()
+	require.Nil(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, governance.ValidatorCount, changes[0].Name)
+	assert.Equal(t, big.NewInt(6), changes[0].Value)
+	assert.False(t, changes[0].Created)
+	assert.Equal(t, uint(1), changes[0].Height)
+	assert.Equal(t, "A", changes[0].TxHash)
+	assert.Equal(t, uint64(1), w.Height())
+
+	changes, err = w.Poll()
+	require.Nil(t, err)
+	assert.Empty(t, changes)
+
+	client.Blocks = append(client.Blocks, resp.BlockResult{Height: 2, Txs: []resp.TransactionResult{
+		{Hash: "B", State: "Fault", Events: []resp.EventResult{valueEvent(event.ValueUpdate, governance.ValidatorCount, 9)}},
+		{Hash: "C", State: "Halt", Events: []resp.EventResult{
+			{Kind: event.TokenSend.String(), Data: "00"},
+			valueEvent(event.ValueCreate, governance.PollVoteLimit, 3),
+		}},
+	}})
+
+	changes, err = w.Poll()
+	require.Nil(t, err)
+	require.Len(t, changes, 1)
+	assert.True(t, changes[0].Created)
+
+	updated := config.Apply(changes...)
+	assert.Equal(t, int64(3), updated.PollVoteLimit)
+	assert.Equal(t, int64(0), config.PollVoteLimit)
+	assert.Equal(t, int64(5), updated.ValidatorCount)
+}
@@ -0,0 +1,121 @@
+package governance
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+)
+
+// DefaultPollInterval is the default interval Watcher checks for new blocks with
+const DefaultPollInterval = 10 * time.Second
+
+// Change is a governance value set by ValueCreate or ValueUpdate event
+type Change struct {
+	Name  string
+	Value *big.Int
+	// Created is true for ValueCreate events
+	Created bool
+
+	event.Location
+}
+
+// ChangeFromEvent decodes ValueCreate or ValueUpdate event, false is returned for other events
+func ChangeFromEvent(e resp.EventResult) (Change, bool, error) {
+	return event.Decode(e, func(kind event.EventKind, br *io.BinReader) Change {
+		var d event.ChainValueEventData
+		d.Deserialize(br)
+		return Change{Name: d.Name, Value: new(big.Int).Set(&d.Value), Created: kind == event.ValueCreate}
+	}, event.ValueCreate, event.ValueUpdate)
+}
+
+// ChangesFromBlock returns governance changes of successful block transactions and of the block itself, in order
+func ChangesFromBlock(block resp.BlockResult) ([]Change, error) {
+	return event.FromBlock(block, ChangeFromEvent)
+}
+
+// Apply returns config with changes applied, the original config is not modified
+func (c Config) Apply(changes ...Change) Config {
+	values := make(Values, len(c.Values)+len(changes))
+	for name, v := range c.Values {
+		values[name] = v
+	}
+	for _, change := range changes {
+		values.Apply(change)
+	}
+
+	applied := NewConfig(values)
+	applied.Nexus = c.Nexus
+	applied.Protocol = c.Protocol
+	return applied
+}
+
+// Watcher scans new blocks of a chain for governance changes
+type Watcher struct {
+	client Client
+	height uint64
+
+	Chain    string
+	Interval time.Duration
+}
+
+// NewWatcher returns watcher of the main chain reporting changes in blocks after height
+func NewWatcher(client Client, height uint64) *Watcher {
+	return &Watcher{client: client, height: height, Chain: "main", Interval: DefaultPollInterval}
+}
+
+// Height returns height of the last scanned block
+func (w *Watcher) Height() uint64 {
+	return w.height
+}
+
+// Poll scans blocks minted since the last call and returns their changes
+func (w *Watcher) Poll() ([]Change, error) {
+	current, err := w.client.GetBlockHeight(w.Chain)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []Change{}
+	if w.height >= current.Uint64() {
+		return changes, nil
+	}
+
+	err = event.ForEachBlock(w.client, w.Chain, w.height+1, current.Uint64(), func(block resp.BlockResult) error {
+		c, err := ChangesFromBlock(block)
+		if err != nil {
+			return err
+		}
+
+		changes = append(changes, c...)
+		w.height++
+		return nil
+	})
+
+	return changes, err
+}
+
+// Watch polls for changes every Interval and calls fn for each of them until context is done or poll fails
+func (w *Watcher) Watch(ctx context.Context, fn func(Change)) error {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		changes, err := w.Poll()
+		for _, c := range changes {
+			fn(c)
+		}
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/domain/contract"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/phantasma-io/phantasma-go/pkg/util"
+)
+
+// ErrInfusionCycle is returned when an NFT is infused, directly or through other NFTs, into itself
+var ErrInfusionCycle = errors.New("infusion cycle")
+
+// InfusionNode is a node of NFT infusion tree. The root and infused NFTs have Content and Children,
+// infused fungible tokens have Amount.
+type InfusionNode struct {
+	Symbol   string
+	Decimals int
+
+	// TokenID is set for NFTs
+	TokenID  string
+	Content  *contract.TokenContent
+	Children []*InfusionNode
+
+	// Amount is set for fungible tokens, in the smallest units of the token
+	Amount *big.Int
+}
+
+// IsFungible returns true if node is an infused fungible token
+func (n *InfusionNode) IsFungible() bool {
+	return n.Amount != nil
+}
+
+// AmountDecimal returns amount of fungible token converted with token decimals, like "1.5"
+func (n *InfusionNode) AmountDecimal() string {
+	if n.Amount == nil {
+		return ""
+	}
+
+	return util.ConvertDecimals(n.Amount, n.Decimals)
+}
+
+// InfusionTotal is an aggregate of infused tokens of a symbol
+type InfusionTotal struct {
+	Symbol   string
+	Decimals int
+	// Amount is the sum of fungible amounts, it's nil for NFTs
+	Amount *big.Int
+	// Count is the number of infusions, for NFTs it's the number of infused tokens
+	Count int
+}
+
+// AmountDecimal returns total amount converted with token decimals
+func (t InfusionTotal) AmountDecimal() string {
+	if t.Amount == nil {
+		return ""
+	}
+
+	return util.ConvertDecimals(t.Amount, t.Decimals)
+}
+
+// Totals returns infused tokens of the whole tree below the node aggregated per symbol, ordered by symbol
+func (n *InfusionNode) Totals() []InfusionTotal {
+	totals := map[string]*InfusionTotal{}
+
+	var walk func(node *InfusionNode)
+	walk = func(node *InfusionNode) {
+		for _, c := range node.Children {
+			t, ok := totals[c.Symbol]
+			if !ok {
+				t = &InfusionTotal{Symbol: c.Symbol, Decimals: c.Decimals}
+				totals[c.Symbol] = t
+			}
+
+			t.Count++
+			if c.IsFungible() {
+				if t.Amount == nil {
+					t.Amount = big.NewInt(0)
+				}
+				t.Amount.Add(t.Amount, c.Amount)
+			}
+
+			walk(c)
+		}
+	}
+	walk(n)
+
+	result := make([]InfusionTotal, 0, len(totals))
+	for _, t := range totals {
+		result = append(result, *t)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Symbol < result[j].Symbol })
+
+	return result
+}
+
+// InfusionTree resolves NFT and all tokens infused into it, recursively. Infused NFTs are fetched
+// level by level in batches. ErrMissingToken is returned if an infused NFT can't be found and
+// ErrInfusionCycle if an NFT is infused into itself.
+func (inv *Inventory) InfusionTree(ref Ref) (*InfusionNode, error) {
+	contents, err := inv.Contents([]Ref{ref})
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := inv.nftNode(ref, contents[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if err := inv.resolveInfusions(root, map[Ref]bool{ref: true}); err != nil {
+		return nil, err
+	}
+
+	return root, nil
+}
+
+// resolveInfusions creates children of NFT node, path holds NFTs from the root to the node
+func (inv *Inventory) resolveInfusions(node *InfusionNode, path map[Ref]bool) error {
+	nfts := []Ref{}
+	for _, infusion := range node.Content.Infusion {
+		info, err := inv.token(infusion.Symbol)
+		if err != nil {
+			return err
+		}
+
+		if info.IsFungible() {
+			node.Children = append(node.Children, &InfusionNode{
+				Symbol:   infusion.Symbol,
+				Decimals: info.Decimals,
+				Amount:   new(big.Int).Set(infusion.Value),
+			})
+			continue
+		}
+
+		ref := Ref{Symbol: infusion.Symbol, TokenID: infusion.Value.String()}
+		if path[ref] {
+			return fmt.Errorf("%w: %s #%s is infused into itself", ErrInfusionCycle, ref.Symbol, ref.TokenID)
+		}
+		nfts = append(nfts, ref)
+	}
+
+	if len(nfts) == 0 {
+		return nil
+	}
+
+	contents, err := inv.Contents(nfts)
+	if err != nil {
+		return err
+	}
+
+	for i, ref := range nfts {
+		child, err := inv.nftNode(ref, contents[i])
+		if err != nil {
+			return err
+		}
+		node.Children = append(node.Children, child)
+
+		path[ref] = true
+		err = inv.resolveInfusions(child, path)
+		delete(path, ref)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (inv *Inventory) nftNode(ref Ref, content contract.TokenContent) (*InfusionNode, error) {
+	info, err := inv.token(ref.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InfusionNode{Symbol: ref.Symbol, Decimals: info.Decimals, TokenID: ref.TokenID, Content: &content}, nil
+}
+
+// token returns token info, infos are cached for CacheTTL
+func (inv *Inventory) token(symbol string) (resp.TokenResult, error) {
+	inv.mu.Lock()
+	e, ok := inv.tokens[symbol]
+	inv.mu.Unlock()
+	if ok && time.Now().Before(e.expires) {
+		return e.info, nil
+	}
+
+	info, err := inv.client.GetToken(symbol, false)
+	if err != nil {
+		return resp.TokenResult{}, fmt.Errorf("cannot get token %s: %w", symbol, err)
+	}
+
+	if inv.CacheTTL > 0 {
+		inv.mu.Lock()
+		inv.tokens[symbol] = tokenEntry{info: info, expires: time.Now().Add(inv.CacheTTL)}
+		inv.mu.Unlock()
+	}
+
+	return info, nil
+}
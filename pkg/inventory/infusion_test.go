@@ -0,0 +1,113 @@
+package inventory_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/inventory"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func nftData(id string, infusions ...resp.TokenPropertyResult) resp.TokenDataResult {
+	return resp.TokenDataResult{ID: id, Series: "0", Mint: id, Infusion: infusions}
+}
+
+func newInfusionClient() *client {
+	return &client{
+		tokens: map[string]resp.TokenResult{
+			"SOUL":  {Symbol: "SOUL", Decimals: 8, Flags: "Transferable, Fungible, Finite, Divisible"},
+			"KCAL":  {Symbol: "KCAL", Decimals: 10, Flags: "Transferable, Fungible, Divisible"},
+			"CROWN": {Symbol: "CROWN", Flags: "Transferable"},
+			"GEM":   {Symbol: "GEM", Flags: "Transferable"},
+		},
+		nfts: map[inventory.Ref]resp.TokenDataResult{
+			{Symbol: "CROWN", TokenID: "1"}: nftData("1",
+				resp.TokenPropertyResult{Key: "SOUL", Value: "150000000"},
+				resp.TokenPropertyResult{Key: "GEM", Value: "10"},
+				resp.TokenPropertyResult{Key: "GEM", Value: "11"}),
+			{Symbol: "GEM", TokenID: "10"}: nftData("10",
+				resp.TokenPropertyResult{Key: "SOUL", Value: "50000000"},
+				resp.TokenPropertyResult{Key: "KCAL", Value: "10000000000"}),
+			{Symbol: "GEM", TokenID: "11"}: nftData("11"),
+		},
+	}
+}
+
+func TestInfusionTree(t *testing.T) {
+	c := newInfusionClient()
+	inv := inventory.New(c)
+
+	root, err := inv.InfusionTree(inventory.Ref{Symbol: "CROWN", TokenID: "1"})
+	require.Nil(t, err)
+	require.Len(t, root.Children, 3)
+	assert.True(t, root.Children[0].IsFungible())
+	assert.Equal(t, "1.5", root.Children[0].AmountDecimal())
+	assert.Equal(t, "10", root.Children[1].TokenID)
+	require.Len(t, root.Children[1].Children, 2)
+	assert.Equal(t, "1", root.Children[1].Children[1].AmountDecimal())
+	assert.Empty(t, root.Children[2].Children)
+
+	// infused GEMs are fetched with a single call
+	assert.Equal(t, [][]string{{"CROWN", "1"}, {"GEM", "10", "11"}}, c.calls)
+
+	totals := root.Totals()
+	require.Len(t, totals, 3)
+	assert.Equal(t, inventory.InfusionTotal{Symbol: "GEM", Count: 2}, totals[0])
+	assert.Equal(t, "KCAL", totals[1].Symbol)
+	assert.Equal(t, "SOUL", totals[2].Symbol)
+	assert.Equal(t, big.NewInt(200000000), totals[2].Amount)
+	assert.Equal(t, "2", totals[2].AmountDecimal())
+	assert.Equal(t, 2, totals[2].Count)
+}
+
+func TestInfusionTreeTokenCache(t *testing.T) {
+	c := newInfusionClient()
+	inv := inventory.New(c)
+	ref := inventory.Ref{Symbol: "CROWN", TokenID: "1"}
+
+	_, err := inv.InfusionTree(ref)
+	require.Nil(t, err)
+	assert.Equal(t, 4, c.infos)
+
+	_, err = inv.InfusionTree(ref)
+	require.Nil(t, err)
+	assert.Equal(t, 4, c.infos)
+
+	// token info expires with content
+	inv.CacheTTL = 100 * time.Millisecond
+	inv.Invalidate()
+	_, err = inv.InfusionTree(ref)
+	require.Nil(t, err)
+	assert.Equal(t, 8, c.infos)
+
+	time.Sleep(150 * time.Millisecond)
+	c.tokens["SOUL"] = resp.TokenResult{Symbol: "SOUL", Decimals: 4, Flags: "Transferable, Fungible"}
+	root, err := inv.InfusionTree(ref)
+	require.Nil(t, err)
+	assert.Equal(t, 12, c.infos)
+	assert.Equal(t, "15000", root.Children[0].AmountDecimal())
+}
+
+func TestInfusionTreeErrors(t *testing.T) {
+	c := newInfusionClient()
+	c.nfts[inventory.Ref{Symbol: "GEM", TokenID: "11"}] = nftData("11", resp.TokenPropertyResult{Key: "CROWN", Value: "1"})
+
+	_, err := inventory.New(c).InfusionTree(inventory.Ref{Symbol: "CROWN", TokenID: "1"})
+	assert.True(t, errors.Is(err, inventory.ErrInfusionCycle))
+
+	c = newInfusionClient()
+	delete(c.nfts, inventory.Ref{Symbol: "GEM", TokenID: "10"})
+
+	_, err = inventory.New(c).InfusionTree(inventory.Ref{Symbol: "CROWN", TokenID: "1"})
+	assert.True(t, errors.Is(err, inventory.ErrMissingToken))
+
+	c = newInfusionClient()
+	delete(c.tokens, "KCAL")
+
+	_, err = inventory.New(c).InfusionTree(inventory.Ref{Symbol: "CROWN", TokenID: "1"})
+	assert.NotNil(t, err)
+}
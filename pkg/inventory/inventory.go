@@ -0,0 +1,291 @@
+// Package inventory lists NFTs owned by an address with their content.
+//
+// Token IDs are taken from account balances, contents are fetched with getNFTs in batches
+// and cached, so paging through a large collection doesn't fetch the same tokens twice.
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/domain/contract"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/nft"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+)
+
+const (
+	// DefaultBatchSize is the default number of NFTs fetched with a single getNFTs call
+	DefaultBatchSize = 50
+	// DefaultCacheTTL is the default time cached NFT content is kept
+	DefaultCacheTTL = 5 * time.Minute
+)
+
+// ErrMissingToken is returned when node doesn't return a requested NFT
+var ErrMissingToken = errors.New("token is missing")
+
+// Client is a subset of RPC methods used by inventory, it's implemented by rpc.PhantasmaRPC
+type Client interface {
+	GetAccount(address string) (resp.AccountResult, error)
+	GetNFTs(symbol string, tokenIDs []string, extended bool) ([]resp.TokenDataResult, error)
+	GetToken(symbol string, extended bool) (resp.TokenResult, error)
+}
+
+// Ref identifies an NFT
+type Ref struct {
+	Symbol  string
+	TokenID string
+}
+
+type cacheEntry struct {
+	content contract.TokenContent
+	expires time.Time
+}
+
+type refsEntry struct {
+	refs    []Ref
+	expires time.Time
+}
+
+type tokenEntry struct {
+	info    resp.TokenResult
+	expires time.Time
+}
+
+// Inventory fetches NFT contents and caches them
+type Inventory struct {
+	client Client
+
+	// BatchSize is the maximum number of NFTs fetched with a single call
+	BatchSize int
+	// CacheTTL is the time NFT content, owned NFTs of an address and token info are cached for, caching is disabled if it's zero
+	CacheTTL time.Duration
+
+	mu       sync.Mutex
+	cache    map[Ref]cacheEntry
+	accounts map[string]refsEntry
+	tokens   map[string]tokenEntry
+}
+
+// New returns inventory with default batch size and cache TTL
+func New(client Client) *Inventory {
+	return &Inventory{
+		client:    client,
+		BatchSize: DefaultBatchSize,
+		CacheTTL:  DefaultCacheTTL,
+		cache:     make(map[Ref]cacheEntry),
+		accounts:  make(map[string]refsEntry),
+		tokens:    make(map[string]tokenEntry),
+	}
+}
+
+// Refs returns all NFTs owned by address ordered by symbol, IDs of a symbol keep the order returned by node.
+// The list is cached for CacheTTL, so paging doesn't request the account again for every page
+func (inv *Inventory) Refs(address string) ([]Ref, error) {
+	if refs, ok := inv.cachedRefs(address); ok {
+		return refs, nil
+	}
+
+	account, err := inv.client.GetAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make([]resp.BalanceResult, 0, len(account.Balances))
+	for _, b := range account.Balances {
+		if len(b.Ids) > 0 {
+			balances = append(balances, b)
+		}
+	}
+	sort.SliceStable(balances, func(i, j int) bool { return balances[i].Symbol < balances[j].Symbol })
+
+	refs := []Ref{}
+	for _, b := range balances {
+		for _, id := range b.Ids {
+			refs = append(refs, Ref{Symbol: b.Symbol, TokenID: id})
+		}
+	}
+	inv.storeRefs(address, refs)
+
+	return refs, nil
+}
+
+// Page returns a page of NFTs owned by address, pages are numbered from 1
+func (inv *Inventory) Page(address string, page, pageSize int) (resp.PaginatedResult[[]contract.TokenContent], error) {
+	if page < 1 || pageSize < 1 {
+		return resp.PaginatedResult[[]contract.TokenContent]{}, fmt.Errorf("invalid page %d or page size %d", page, pageSize)
+	}
+
+	refs, err := inv.Refs(address)
+	if err != nil {
+		return resp.PaginatedResult[[]contract.TokenContent]{}, err
+	}
+
+	start := min((page-1)*pageSize, len(refs))
+	end := min(start+pageSize, len(refs))
+	contents, err := inv.Contents(refs[start:end])
+	if err != nil {
+		return resp.PaginatedResult[[]contract.TokenContent]{}, err
+	}
+
+	return resp.PaginatedResult[[]contract.TokenContent]{
+		Page:       uint(page),
+		PageSize:   uint(pageSize),
+		Total:      uint(len(refs)),
+		TotalPages: uint((len(refs) + pageSize - 1) / pageSize),
+		Result:     contents,
+	}, nil
+}
+
+// All returns all NFTs owned by address
+func (inv *Inventory) All(address string) ([]contract.TokenContent, error) {
+	refs, err := inv.Refs(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return inv.Contents(refs)
+}
+
+// Contents returns contents of NFTs in the order of refs, NFTs missing in cache are fetched in batches per symbol
+func (inv *Inventory) Contents(refs []Ref) ([]contract.TokenContent, error) {
+	result := make([]contract.TokenContent, len(refs))
+	missing := map[string][]int{}
+	symbols := []string{}
+
+	for i, ref := range refs {
+		if c, ok := inv.cached(ref); ok {
+			result[i] = c
+			continue
+		}
+
+		if _, ok := missing[ref.Symbol]; !ok {
+			symbols = append(symbols, ref.Symbol)
+		}
+		missing[ref.Symbol] = append(missing[ref.Symbol], i)
+	}
+
+	batchSize := inv.BatchSize
+	if batchSize < 1 {
+		batchSize = DefaultBatchSize
+	}
+
+	for _, symbol := range symbols {
+		indexes := missing[symbol]
+		for start := 0; start < len(indexes); start += batchSize {
+			batch := indexes[start:min(start+batchSize, len(indexes))]
+			if err := inv.fetch(symbol, refs, batch, result); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// fetch loads contents of refs with given indexes into result
+func (inv *Inventory) fetch(symbol string, refs []Ref, indexes []int, result []contract.TokenContent) error {
+	ids := make([]string, len(indexes))
+	for i, index := range indexes {
+		ids[i] = refs[index].TokenID
+	}
+
+	data, err := inv.client.GetNFTs(symbol, ids, true)
+	if err != nil {
+		return fmt.Errorf("cannot get %s NFTs: %w", symbol, err)
+	}
+
+	contents := make(map[string]contract.TokenContent, len(data))
+	for _, d := range data {
+		c, err := nft.ContentFromTokenData(symbol, d)
+		if err != nil {
+			return fmt.Errorf("%s #%s: %w", symbol, d.ID, err)
+		}
+		contents[d.ID] = c
+	}
+
+	for _, index := range indexes {
+		ref := refs[index]
+		c, ok := contents[ref.TokenID]
+		if !ok {
+			return fmt.Errorf("%w: %s #%s is not returned by node", ErrMissingToken, symbol, ref.TokenID)
+		}
+		result[index] = c
+		inv.store(ref, c)
+	}
+
+	return nil
+}
+
+func (inv *Inventory) cached(ref Ref) (contract.TokenContent, bool) {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	e, ok := inv.cache[ref]
+	if !ok || !time.Now().Before(e.expires) {
+		return contract.TokenContent{}, false
+	}
+
+	return e.content, true
+}
+
+func (inv *Inventory) store(ref Ref, c contract.TokenContent) {
+	if inv.CacheTTL <= 0 {
+		return
+	}
+
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	inv.cache[ref] = cacheEntry{content: c, expires: time.Now().Add(inv.CacheTTL)}
+}
+
+func (inv *Inventory) cachedRefs(address string) ([]Ref, bool) {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	e, ok := inv.accounts[address]
+	if !ok || !time.Now().Before(e.expires) {
+		return nil, false
+	}
+
+	return e.refs, true
+}
+
+func (inv *Inventory) storeRefs(address string, refs []Ref) {
+	if inv.CacheTTL <= 0 {
+		return
+	}
+
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	inv.accounts[address] = refsEntry{refs: refs, expires: time.Now().Add(inv.CacheTTL)}
+}
+
+// InvalidateAccount removes cached list of NFTs owned by address, it should be called after NFTs are sent or received
+func (inv *Inventory) InvalidateAccount(address string) {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	delete(inv.accounts, address)
+}
+
+// Invalidate removes NFTs from cache, the whole cache including owned NFTs of all addresses and token info is cleared if no refs are given
+func (inv *Inventory) Invalidate(refs ...Ref) {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	if len(refs) == 0 {
+		inv.cache = make(map[Ref]cacheEntry)
+		inv.accounts = make(map[string]refsEntry)
+		inv.tokens = make(map[string]tokenEntry)
+		return
+	}
+
+	for _, ref := range refs {
+		delete(inv.cache, ref)
+	}
+}
@@ -0,0 +1,143 @@
+package inventory_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/phantasma-io/phantasma-go/pkg/inventory"
+	"github.com/phantasma-io/phantasma-go/pkg/rpc"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ inventory.Client = rpc.PhantasmaRPC{}
+
+type client struct {
+	balances []resp.BalanceResult
+	calls    [][]string
+	accounts int
+	infos    int
+
+	// nfts override generated NFT data, keyed by symbol and token ID
+	nfts   map[inventory.Ref]resp.TokenDataResult
+	tokens map[string]resp.TokenResult
+}
+
+func (c *client) GetToken(symbol string, extended bool) (resp.TokenResult, error) {
+	c.infos++
+	t, ok := c.tokens[symbol]
+	if !ok {
+		return resp.TokenResult{}, fmt.Errorf("token %s not found", symbol)
+	}
+	return t, nil
+}
+
+func (c *client) GetAccount(address string) (resp.AccountResult, error) {
+	c.accounts++
+	return resp.AccountResult{Address: address, Balances: c.balances}, nil
+}
+
+func (c *client) GetNFTs(symbol string, tokenIDs []string, extended bool) ([]resp.TokenDataResult, error) {
+	c.calls = append(c.calls, append([]string{symbol}, tokenIDs...))
+
+	result := []resp.TokenDataResult{}
+	for _, id := range tokenIDs {
+		if c.nfts != nil {
+			if data, ok := c.nfts[inventory.Ref{Symbol: symbol, TokenID: id}]; ok {
+				result = append(result, data)
+			}
+			continue
+		}
+
+		result = append(result, resp.TokenDataResult{
+			ID:       id,
+			Series:   "1",
+			Mint:     id,
+			ROM:      "0102",
+			RAM:      "",
+			Infusion: []resp.TokenPropertyResult{{Key: "SOUL", Value: "100000000"}},
+		})
+	}
+	return result, nil
+}
+
+func TestInventory(t *testing.T) {
+	c := &client{balances: []resp.BalanceResult{
+		{Symbol: "SOUL", Amount: "100"},
+		{Symbol: "TTRS", Ids: []string{"7", "8"}},
+		{Symbol: "CROWN", Ids: []string{"1", "2", "3"}},
+	}}
+
+	inv := inventory.New(c)
+	inv.BatchSize = 2
+
+	page, err := inv.Page("P2K", 1, 4)
+	require.Nil(t, err)
+	assert.Equal(t, uint(5), page.Total)
+	assert.Equal(t, uint(2), page.TotalPages)
+	require.Len(t, page.Result, 4)
+	assert.Equal(t, "CROWN", page.Result[0].Symbol)
+	assert.Equal(t, big.NewInt(1), page.Result[0].TokenID)
+	assert.Equal(t, []byte{1, 2}, page.Result[0].ROM)
+	assert.Equal(t, "SOUL", page.Result[0].Infusion[0].Symbol)
+	assert.Equal(t, big.NewInt(100000000), page.Result[0].Infusion[0].Value)
+	assert.Equal(t, "TTRS", page.Result[3].Symbol)
+	assert.Equal(t, [][]string{{"CROWN", "1", "2"}, {"CROWN", "3"}, {"TTRS", "7"}}, c.calls)
+
+	all, err := inv.All("P2K")
+	require.Nil(t, err)
+	require.Len(t, all, 5)
+	assert.Equal(t, big.NewInt(8), all[4].TokenID)
+	// only the NFT which was not on the first page is fetched
+	assert.Equal(t, []string{"TTRS", "8"}, c.calls[3])
+	assert.Len(t, c.calls, 4)
+
+	inv.Invalidate(inventory.Ref{Symbol: "CROWN", TokenID: "2"})
+	_, err = inv.All("P2K")
+	require.Nil(t, err)
+	assert.Equal(t, []string{"CROWN", "2"}, c.calls[4])
+
+	page, err = inv.Page("P2K", 3, 4)
+	require.Nil(t, err)
+	assert.Empty(t, page.Result)
+
+	_, err = inv.Page("P2K", 0, 4)
+	assert.NotNil(t, err)
+	// account is requested once, refs are cached with contents
+	assert.Equal(t, 1, c.accounts)
+}
+
+func TestInventoryAccountInvalidation(t *testing.T) {
+	c := &client{balances: []resp.BalanceResult{{Symbol: "CROWN", Ids: []string{"1"}}}}
+
+	inv := inventory.New(c)
+	_, err := inv.Page("P2K", 1, 1)
+	require.Nil(t, err)
+
+	c.balances = []resp.BalanceResult{{Symbol: "CROWN", Ids: []string{"1", "2"}}}
+	page, err := inv.Page("P2K", 1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, uint(1), page.Total)
+
+	inv.InvalidateAccount("P2K")
+	page, err = inv.Page("P2K", 2, 1)
+	require.Nil(t, err)
+	assert.Equal(t, uint(2), page.Total)
+	assert.Equal(t, 2, c.accounts)
+	assert.Equal(t, [][]string{{"CROWN", "1"}, {"CROWN", "2"}}, c.calls)
+}
+
+func TestInventoryWithoutCache(t *testing.T) {
+	c := &client{balances: []resp.BalanceResult{{Symbol: "CROWN", Ids: []string{"1"}}}}
+
+	inv := inventory.New(c)
+	inv.CacheTTL = 0
+	for i := 0; i < 2; i++ {
+		_, err := inv.All("P2K")
+		require.Nil(t, err)
+	}
+	assert.Len(t, c.calls, 2, fmt.Sprint(c.calls))
+	assert.Equal(t, 2, c.accounts)
+}
@@ -179,6 +179,9 @@ func (r *BinReader) ReadBigInteger() *big.Int {
 	}
 
 	b := r.ReadVarBytes()
+	if r.Err != nil || len(b) == 0 {
+		return big.NewInt(0)
+	}
 
 	return util.BigIntFromCsharpOrPhantasmaByteArray(b)
 }
@@ -523,3 +523,13 @@ func TestBinRW_ReadWriteNegativeNumber(t *testing.T) {
 	readWriteNumberTest(t, "-257")
 	readWriteNumberTest(t, "-99999999999999999999999999999999999999999999999999")
 }
+
+func TestBinRW_ReadTruncatedNumber(t *testing.T) {
+	r := NewBinReaderFromBuf([]byte{})
+	require.Equal(t, big.NewInt(0), r.ReadBigInteger())
+	require.Error(t, r.Err)
+
+	r = NewBinReaderFromBuf([]byte{0})
+	require.Equal(t, big.NewInt(0), r.ReadBigInteger())
+	require.NoError(t, r.Err)
+}
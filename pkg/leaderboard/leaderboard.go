@@ -0,0 +1,108 @@
+// Package leaderboard reads leaderboards of the ranking contract with typed scores and decodes leaderboard events.
+package leaderboard
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+)
+
+// Client is a subset of RPC methods used by leaderboard, it's implemented by rpc.PhantasmaRPC
+type Client interface {
+	GetLeaderboard(name string) (resp.LeaderboardResult, error)
+}
+
+// Row is a score of an address, rank of the best score is 1
+type Row struct {
+	Rank    int
+	Address string
+	Score   *big.Int
+}
+
+// Leaderboard is a leaderboard with rows ordered by score, best first
+type Leaderboard struct {
+	Name string
+	Rows []Row
+}
+
+// New converts leaderboard returned by getLeaderboard, rows with equal scores keep the order returned by node
+func New(r resp.LeaderboardResult) (Leaderboard, error) {
+	rows := make([]Row, len(r.Rows))
+	for i, row := range r.Rows {
+		score, ok := new(big.Int).SetString(row.Value, 10)
+		if !ok {
+			return Leaderboard{}, fmt.Errorf("invalid score %q of %s", row.Value, row.Address)
+		}
+		rows[i] = Row{Address: row.Address, Score: score}
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Score.Cmp(rows[j].Score) > 0 })
+	for i := range rows {
+		rows[i].Rank = i + 1
+	}
+
+	return Leaderboard{Name: r.Name, Rows: rows}, nil
+}
+
+// Get returns leaderboard by name
+func Get(client Client, name string) (Leaderboard, error) {
+	r, err := client.GetLeaderboard(name)
+	if err != nil {
+		return Leaderboard{}, err
+	}
+
+	return New(r)
+}
+
+// Top returns n best rows, or all rows if there are fewer
+func (l Leaderboard) Top(n int) []Row {
+	return l.Rows[:min(max(n, 0), len(l.Rows))]
+}
+
+// Row returns row of the address, false is returned if address has no score
+func (l Leaderboard) Row(address string) (Row, bool) {
+	for _, r := range l.Rows {
+		if r.Address == address {
+			return r, true
+		}
+	}
+
+	return Row{}, false
+}
+
+// Event is a LeaderboardCreate, LeaderboardInsert or LeaderboardReset event
+type Event struct {
+	Kind event.EventKind
+	Name string
+	// Address is the creator or resetter of leaderboard, or the address score is inserted for
+	Address string
+	// Score is set for LeaderboardInsert events
+	Score *big.Int
+
+	event.Location
+}
+
+// EventFromEvent decodes leaderboard event, false is returned for other events
+func EventFromEvent(e resp.EventResult) (Event, bool, error) {
+	return event.Decode(e, func(kind event.EventKind, br *io.BinReader) Event {
+		le := Event{Kind: kind, Address: e.Address}
+		if kind == event.LeaderboardInsert {
+			var d event.LeaderboardEventData
+			d.Deserialize(br)
+			le.Name = d.Name
+			le.Score = d.Value
+		} else {
+			le.Name = br.ReadString()
+		}
+		return le
+	}, event.LeaderboardCreate, event.LeaderboardInsert, event.LeaderboardReset)
+}
+
+// EventsFromBlock returns leaderboard events of successful block transactions and of the block itself, in order
+func EventsFromBlock(block resp.BlockResult) ([]Event, error) {
+	return event.FromBlock(block, EventFromEvent)
+}
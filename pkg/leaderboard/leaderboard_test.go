@@ -0,0 +1,91 @@
+package leaderboard_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event/eventtest"
+	"github.com/phantasma-io/phantasma-go/pkg/leaderboard"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient map[string]resp.LeaderboardResult
+
+func (c fakeClient) GetLeaderboard(name string) (resp.LeaderboardResult, error) {
+	l, ok := c[name]
+	if !ok {
+		return resp.LeaderboardResult{}, errors.New("leaderboard not found")
+	}
+
+	return l, nil
+}
+
+func TestGet(t *testing.T) {
+	client := fakeClient{
+		"season1": {Name: "season1", Rows: []resp.LeaderboardRowResult{
+			{Address: "alice", Value: "150"},
+			{Address: "bob", Value: "99999999999999999999999"},
+			{Address: "carol", Value: "150"},
+		}},
+		"broken": {Name: "broken", Rows: []resp.LeaderboardRowResult{{Address: "alice", Value: "1e3"}}},
+	}
+
+	l, err := leaderboard.Get(client, "season1")
+	require.Nil(t, err)
+	assert.Equal(t, "season1", l.Name)
+
+	score, _ := new(big.Int).SetString("99999999999999999999999", 10)
+	assert.Equal(t, []leaderboard.Row{
+		{Rank: 1, Address: "bob", Score: score},
+		{Rank: 2, Address: "alice", Score: big.NewInt(150)},
+		{Rank: 3, Address: "carol", Score: big.NewInt(150)},
+	}, l.Rows)
+
+	assert.Len(t, l.Top(2), 2)
+	assert.Len(t, l.Top(10), 3)
+	assert.Empty(t, l.Top(-1))
+
+	row, ok := l.Row("carol")
+	assert.True(t, ok)
+	assert.Equal(t, 3, row.Rank)
+	_, ok = l.Row("dave")
+	assert.False(t, ok)
+
+	_, err = leaderboard.Get(client, "broken")
+	assert.NotNil(t, err)
+	_, err = leaderboard.Get(client, "unknown")
+	assert.NotNil(t, err)
+}
+
+func TestEvents(t *testing.T) {
+	created := eventtest.StringEvent(event.LeaderboardCreate, "owner", "season1")
+	reset := eventtest.StringEvent(event.LeaderboardReset, "owner", "season1")
+	insert := eventtest.Event(event.LeaderboardInsert, "alice", &event.LeaderboardEventData{Name: "season1", Value: big.NewInt(-42)})
+
+	events, err := leaderboard.EventsFromBlock(resp.BlockResult{Height: 7, Txs: []resp.TransactionResult{
+		{Hash: "A", State: "Halt", Events: []resp.EventResult{
+			created,
+			insert,
+			{Kind: event.TokenSend.String(), Data: "00"},
+		}},
+		{Hash: "B", State: "Fault", Events: []resp.EventResult{
+			reset,
+		}},
+		{Hash: "C", State: "Halt", Events: []resp.EventResult{
+			reset,
+		}},
+	}})
+	require.Nil(t, err)
+	assert.Equal(t, []leaderboard.Event{
+		{Kind: event.LeaderboardCreate, Name: "season1", Address: "owner", Location: event.Location{Height: 7, TxHash: "A"}},
+		{Kind: event.LeaderboardInsert, Name: "season1", Address: "alice", Score: big.NewInt(-42), Location: event.Location{Height: 7, TxHash: "A"}},
+		{Kind: event.LeaderboardReset, Name: "season1", Address: "owner", Location: event.Location{Height: 7, TxHash: "C"}},
+	}, events)
+
+	_, _, err = leaderboard.EventFromEvent(resp.EventResult{Kind: event.LeaderboardInsert.String(), Data: "00"})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,162 @@
+// Package organization queries organization members and tracks membership changes.
+//
+// Organizations are groups of addresses identified by ID. Membership of Validators,
+// Masters and Stakers is managed by validator and stake contracts, other organizations
+// are managed with Runtime.AddMember and Runtime.RemoveMember interops.
+package organization
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+)
+
+// IDs of organizations created with the nexus
+const (
+	Validators = "validators"
+	Masters    = "masters"
+	Stakers    = "stakers"
+)
+
+// Client is a subset of RPC methods used by organization, it's implemented by rpc.PhantasmaRPC
+type Client interface {
+	GetOrganization(id string, extended bool) (resp.OrganizationResult, error)
+	GetBlockByHeight(chain string, height string) (resp.BlockResult, error)
+}
+
+// Members returns addresses of organization members
+func Members(client Client, id string) ([]string, error) {
+	org, err := client.GetOrganization(id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if org.Members == nil {
+		return []string{}, nil
+	}
+
+	return org.Members, nil
+}
+
+// IsMember returns true if address is a member of organization
+func IsMember(client Client, id, address string) (bool, error) {
+	members, err := Members(client, id)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(members, address), nil
+}
+
+// Change is an organization created by OrganizationCreate event or a member
+// added or removed by OrganizationAdd and OrganizationRemove events
+type Change struct {
+	Kind         event.EventKind
+	Organization string
+	// Member is empty for OrganizationCreate events
+	Member string
+	// Address is the address event was emitted by, it's the creator of organization for OrganizationCreate events
+	Address string
+
+	event.Location
+}
+
+// ChangeFromEvent decodes organization event, false is returned for other events
+func ChangeFromEvent(e resp.EventResult) (Change, bool, error) {
+	return event.Decode(e, func(kind event.EventKind, br *io.BinReader) Change {
+		c := Change{Kind: kind, Address: e.Address}
+		if kind == event.OrganizationCreate {
+			c.Organization = br.ReadString()
+		} else {
+			var d event.OrganizationEventData
+			d.Deserialize(br)
+			c.Organization = d.Organization
+			c.Member = d.MemberAddress.String()
+		}
+		return c
+	}, event.OrganizationCreate, event.OrganizationAdd, event.OrganizationRemove)
+}
+
+// ChangesFromBlock returns organization changes of successful block transactions and of the block itself, in order
+func ChangesFromBlock(block resp.BlockResult) ([]Change, error) {
+	return event.FromBlock(block, ChangeFromEvent)
+}
+
+// Changes returns organization changes in blocks from first to last height, both inclusive
+func Changes(client Client, chain string, first, last uint64) ([]Change, error) {
+	return event.FromBlocks(client, chain, first, last, ChangeFromEvent)
+}
+
+// Diff is a net membership change of an organization, members added and then removed are not included
+type Diff struct {
+	Added   []string
+	Removed []string
+}
+
+// Summarize returns net membership changes by organization, addresses are sorted
+func Summarize(changes []Change) map[string]Diff {
+	type span struct{ first, last event.EventKind }
+	spans := map[string]map[string]*span{}
+
+	for _, c := range changes {
+		if c.Kind == event.OrganizationCreate {
+			continue
+		}
+
+		members, ok := spans[c.Organization]
+		if !ok {
+			members = map[string]*span{}
+			spans[c.Organization] = members
+		}
+
+		if s, ok := members[c.Member]; ok {
+			s.last = c.Kind
+		} else {
+			members[c.Member] = &span{first: c.Kind, last: c.Kind}
+		}
+	}
+
+	diffs := map[string]Diff{}
+	for org, members := range spans {
+		d := Diff{Added: []string{}, Removed: []string{}}
+		for member, s := range members {
+			// a member absent before the range is added first, a present one is removed first
+			if s.first != s.last {
+				continue
+			}
+			if s.last == event.OrganizationAdd {
+				d.Added = append(d.Added, member)
+			} else {
+				d.Removed = append(d.Removed, member)
+			}
+		}
+		sort.Strings(d.Added)
+		sort.Strings(d.Removed)
+		diffs[org] = d
+	}
+
+	return diffs
+}
+
+// Apply returns members of organization after changes, members is not modified
+func Apply(organization string, members []string, changes []Change) []string {
+	result := slices.Clone(members)
+	for _, c := range changes {
+		if c.Organization != organization {
+			continue
+		}
+
+		i := slices.Index(result, c.Member)
+		switch {
+		case c.Kind == event.OrganizationAdd && i < 0:
+			result = append(result, c.Member)
+		case c.Kind == event.OrganizationRemove && i >= 0:
+			result = slices.Delete(result, i, i+1)
+		}
+	}
+
+	return result
+}
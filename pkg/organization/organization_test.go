@@ -0,0 +1,95 @@
+package organization_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event/eventtest"
+	"github.com/phantasma-io/phantasma-go/pkg/organization"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	organizations map[string]resp.OrganizationResult
+	eventtest.Blocks
+}
+
+func (c *fakeClient) GetOrganization(id string, extended bool) (resp.OrganizationResult, error) {
+	org, ok := c.organizations[id]
+	if !ok {
+		return resp.OrganizationResult{}, errors.New("organization not found")
+	}
+
+	return org, nil
+}
+
+func memberEvent(kind event.EventKind, org string, member cryptography.Address) resp.EventResult {
+	return eventtest.Event(kind, member.String(), &event.OrganizationEventData{Organization: org, MemberAddress: member})
+}
+
+func TestMembers(t *testing.T) {
+	client := &fakeClient{organizations: map[string]resp.OrganizationResult{
+		organization.Masters: {ID: organization.Masters, Name: "Soul Masters", Members: []string{eventtest.Address(1).String()}},
+		"empty":              {ID: "empty"},
+	}}
+
+	members, err := organization.Members(client, organization.Masters)
+	require.Nil(t, err)
+	assert.Equal(t, []string{eventtest.Address(1).String()}, members)
+
+	members, err = organization.Members(client, "empty")
+	require.Nil(t, err)
+	assert.Empty(t, members)
+
+	ok, err := organization.IsMember(client, organization.Masters, eventtest.Address(2).String())
+	require.Nil(t, err)
+	assert.False(t, ok)
+
+	_, err = organization.IsMember(client, "unknown", eventtest.Address(1).String())
+	assert.NotNil(t, err)
+}
+
+func TestChanges(t *testing.T) {
+	a, b, c := eventtest.Address(1), eventtest.Address(2), eventtest.Address(3)
+
+	created := eventtest.StringEvent(event.OrganizationCreate, a.String(), "guild")
+
+	client := &fakeClient{Blocks: eventtest.Blocks{
+		{Height: 1, Txs: []resp.TransactionResult{{Hash: "A", State: "Halt", Events: []resp.EventResult{
+			created,
+			memberEvent(event.OrganizationAdd, "guild", a),
+			memberEvent(event.OrganizationAdd, "guild", b),
+		}}}},
+		{Height: 2, Txs: []resp.TransactionResult{
+			{Hash: "B", State: "Fault", Events: []resp.EventResult{memberEvent(event.OrganizationRemove, "guild", a)}},
+			{Hash: "C", State: "Halt", Events: []resp.EventResult{
+				memberEvent(event.OrganizationRemove, "guild", b),
+				memberEvent(event.OrganizationRemove, organization.Masters, c),
+				{Kind: event.TokenSend.String(), Data: "00"},
+			}},
+		}},
+	}}
+
+	changes, err := organization.Changes(client, "main", 1, 2)
+	require.Nil(t, err)
+	require.Len(t, changes, 5)
+	assert.Equal(t, organization.Change{Kind: event.OrganizationCreate, Organization: "guild", Address: a.String(), Location: event.Location{Height: 1, TxHash: "A"}}, changes[0])
+	assert.Equal(t, b.String(), changes[2].Member)
+	assert.Equal(t, "C", changes[3].TxHash)
+
+	diffs := organization.Summarize(changes)
+	assert.Equal(t, organization.Diff{Added: []string{a.String()}, Removed: []string{}}, diffs["guild"])
+	assert.Equal(t, organization.Diff{Added: []string{}, Removed: []string{c.String()}}, diffs[organization.Masters])
+
+	members := []string{c.String()}
+	assert.Equal(t, []string{a.String()}, organization.Apply("guild", nil, changes))
+	assert.Empty(t, organization.Apply(organization.Masters, members, changes))
+	assert.Equal(t, []string{c.String()}, members)
+
+	_, err = organization.Changes(client, "main", 2, 3)
+	assert.NotNil(t, err)
+}
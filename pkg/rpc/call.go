@@ -0,0 +1,123 @@
+package rpc
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+)
+
+// ContractCall describes a read-only contract method call
+type ContractCall struct {
+	Contract string
+	Method   string
+	Args     []interface{}
+
+	// Result is an optional pointer the returned value is stored into with vm.Unmarshal
+	Result interface{}
+}
+
+// NewContractCall returns a call of contract method
+func NewContractCall(contract, method string, args ...interface{}) ContractCall {
+	return ContractCall{Contract: contract, Method: method, Args: args}
+}
+
+// Into sets a pointer the returned value is unmarshaled into
+func (c ContractCall) Into(result interface{}) ContractCall {
+	c.Result = result
+	return c
+}
+
+// Call invokes a read-only contract method and returns its result
+func (rpc PhantasmaRPC) Call(ctx context.Context, chain, contract, method string, args ...interface{}) (*vm.VMObject, error) {
+	results, err := rpc.MultiCall(ctx, chain, NewContractCall(contract, method, args...))
+	if err != nil {
+		return nil, err
+	}
+
+	return results[0], nil
+}
+
+// MultiCall invokes several read-only contract methods in a single script. Every method
+// must return a value, results are returned in the order of calls and are also
+// unmarshaled into ContractCall.Result if it's set.
+func (rpc PhantasmaRPC) MultiCall(ctx context.Context, chain string, calls ...ContractCall) ([]*vm.VMObject, error) {
+	if len(calls) == 0 {
+		return nil, fmt.Errorf("no calls to invoke")
+	}
+
+	script, err := buildCallScript(calls)
+	if err != nil {
+		return nil, err
+	}
+
+	scriptResult, err := rpc.invokeRawScript(ctx, chain, hex.EncodeToString(script))
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := decodeResults(scriptResult)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) != len(calls) {
+		return nil, fmt.Errorf("script returned %d values for %d calls", len(results), len(calls))
+	}
+
+	for i, c := range calls {
+		if c.Result == nil {
+			continue
+		}
+
+		if err := vm.Unmarshal(results[i], c.Result); err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", c.Contract, c.Method, err)
+		}
+	}
+
+	return results, nil
+}
+
+func buildCallScript(calls []ContractCall) (script []byte, err error) {
+	// script builder panics on arguments it can't load
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cannot build script: %v", r)
+		}
+	}()
+
+	sb := scriptbuilder.BeginScript()
+	for _, c := range calls {
+		sb = sb.CallContract(c.Contract, c.Method, c.Args...)
+	}
+
+	return sb.EndScript(), nil
+}
+
+func decodeResults(scriptResult resp.ScriptResult) ([]*vm.VMObject, error) {
+	encoded := scriptResult.Results
+	if len(encoded) == 0 && scriptResult.Result != "" {
+		encoded = []string{scriptResult.Result}
+	}
+
+	results := make([]*vm.VMObject, len(encoded))
+	for i, e := range encoded {
+		data, err := hex.DecodeString(e)
+		if err != nil {
+			return nil, fmt.Errorf("invalid result %d: %w", i, err)
+		}
+
+		br := io.NewBinReaderFromBuf(data)
+		results[i] = &vm.VMObject{}
+		results[i].Deserialize(br)
+		if br.Err != nil {
+			return nil, fmt.Errorf("invalid result %d: %w", i, br.Err)
+		}
+	}
+
+	return results, nil
+}
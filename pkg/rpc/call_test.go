@@ -0,0 +1,64 @@
+package rpc_test
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/phantasma-io/phantasma-go/pkg/blockchain"
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/rpc"
+	"github.com/phantasma-io/phantasma-go/pkg/simulator"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCall(t *testing.T) {
+	staker := cryptography.NewPhantasmaKeys(make([]byte, 32))
+	other := cryptography.NewPhantasmaKeys(append(make([]byte, 31), 1))
+
+	nexus := simulator.NewNexus()
+	require.Nil(t, nexus.Mint("SOUL", staker.Address(), big.NewInt(1000_00000000)))
+	require.Nil(t, nexus.Mint("KCAL", staker.Address(), big.NewInt(100_0000000000)))
+
+	script := scriptbuilder.BeginScript().
+		AllowGas(staker.Address(), cryptography.NullAddress(), big.NewInt(100000), big.NewInt(10000)).
+		Stake(staker.Address(), big.NewInt(100_00000000)).
+		SpendGas(staker.Address()).
+		EndScript()
+	tx := blockchain.NewTransaction(nexus.Name, simulator.ChainName, script, nexus.Time()+60, nil)
+	tx.Sign(staker)
+	record, err := nexus.SendTransaction(tx)
+	require.Nil(t, err)
+	require.Equal(t, vm.Halt, record.State)
+
+	server := httptest.NewServer(simulator.NewServer(nexus))
+	defer server.Close()
+	client := rpc.NewRPC(server.URL)
+	ctx := context.Background()
+
+	result, err := client.Call(ctx, "main", "stake", "GetStake", staker.Address())
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(100_00000000), result.AsNumber())
+
+	var stakerStake, otherStake *big.Int
+	results, err := client.MultiCall(ctx, "main",
+		rpc.NewContractCall("stake", "GetStake", staker.Address()).Into(&stakerStake),
+		rpc.NewContractCall("stake", "GetStake", other.Address()).Into(&otherStake))
+	require.Nil(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, big.NewInt(100_00000000), stakerStake)
+	assert.Equal(t, big.NewInt(0), otherStake)
+
+	_, err = client.Call(ctx, "main", "stake", "Unknown")
+	assert.NotNil(t, err)
+
+	_, err = client.Call(ctx, "main", "stake", "GetStake", 1.5)
+	assert.NotNil(t, err)
+
+	_, err = client.MultiCall(ctx, "main")
+	assert.NotNil(t, err)
+}
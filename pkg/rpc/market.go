@@ -0,0 +1,113 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/domain/market"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+)
+
+// auctionsPageSize is the page size used to fetch all auctions of a token
+const auctionsPageSize = 100
+
+// GetAuctionsCount returns number of auctions of NFTs with given symbol, all auctions are counted if symbol is empty
+func (rpc PhantasmaRPC) GetAuctionsCount(chain, symbol string) (int, error) {
+	var count int
+	result, err := rpc.client.Call(context.Background(), "getAuctionsCount", chain, symbol)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := checkError(err, result.Error); err != nil {
+		return 0, err
+	}
+
+	if err := result.GetObject(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetAuctions returns a page of auctions of NFTs with given symbol, all auctions are returned if symbol is empty
+func (rpc PhantasmaRPC) GetAuctions(chain, symbol string, page, pageSize int) (resp.PaginatedResult[[]resp.AuctionResult], error) {
+	var auctions resp.PaginatedResult[[]resp.AuctionResult]
+	result, err := rpc.client.Call(context.Background(), "getAuctions", chain, symbol, page, pageSize)
+	if err != nil {
+		return auctions, err
+	}
+
+	if err := checkError(err, result.Error); err != nil {
+		return auctions, err
+	}
+
+	if err := result.GetObject(&auctions); err != nil {
+		return auctions, err
+	}
+
+	return auctions, nil
+}
+
+// GetAuction returns auction of the NFT
+func (rpc PhantasmaRPC) GetAuction(chain, symbol string, tokenID *big.Int) (resp.AuctionResult, error) {
+	var auction resp.AuctionResult
+	result, err := rpc.client.Call(context.Background(), "getAuction", chain, symbol, tokenID.String())
+	if err != nil {
+		return auction, err
+	}
+
+	if err := checkError(err, result.Error); err != nil {
+		return auction, err
+	}
+
+	if err := result.GetObject(&auction); err != nil {
+		return auction, err
+	}
+
+	return auction, nil
+}
+
+// GetActiveAuctions returns auctions of NFTs with given symbol which are active now.
+// Prices are decoded with decimals of auction quote tokens.
+func (rpc PhantasmaRPC) GetActiveAuctions(chain, symbol string) ([]market.Auction, error) {
+	decimals := map[string]int{}
+	now := time.Now()
+	auctions := []market.Auction{}
+
+	for page := 1; ; page++ {
+		result, err := rpc.GetAuctions(chain, symbol, page, auctionsPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range result.Result {
+			d, ok := decimals[r.QuoteSymbol]
+			if !ok {
+				token, err := rpc.GetToken(r.QuoteSymbol, false)
+				if err != nil {
+					return nil, fmt.Errorf("cannot get quote token %s: %w", r.QuoteSymbol, err)
+				}
+				d = token.Decimals
+				decimals[r.QuoteSymbol] = d
+			}
+
+			auction, err := market.NewAuction(r, d)
+			if err != nil {
+				return nil, fmt.Errorf("auction of %s #%s: %w", r.BaseSymbol, r.TokenID, err)
+			}
+
+			if auction.IsActive(now) {
+				auctions = append(auctions, auction)
+			}
+		}
+
+		if page >= int(result.TotalPages) {
+			break
+		}
+	}
+
+	return auctions, nil
+}
@@ -0,0 +1,80 @@
+package rpc_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/rpc"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubNode answers JSON-RPC requests with results returned by handle.
+// Client sends ids as strings but expects numbers in responses.
+func stubNode(t *testing.T, handle func(method string, params []json.RawMessage) interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     string            `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		id, _ := strconv.Atoi(req.ID)
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      id,
+			"result":  handle(req.Method, req.Params),
+		})
+	}))
+}
+
+func TestGetActiveAuctions(t *testing.T) {
+	creator := cryptography.NewPhantasmaKeys(make([]byte, 32)).Address()
+	now := uint(time.Now().Unix())
+
+	auction := func(id string, start, end uint) resp.AuctionResult {
+		return resp.AuctionResult{
+			CreatorAddress: creator.String(),
+			StartDate:      start,
+			EndDate:        end,
+			BaseSymbol:     "CROWN",
+			QuoteSymbol:    "SOUL",
+			TokenID:        id,
+			Price:          "150000000",
+			EndPrice:       "0",
+			Type:           "Fixed",
+		}
+	}
+	pages := [][]resp.AuctionResult{
+		{auction("1", now-60, now+3600), auction("2", now-7200, now-3600)},
+		{auction("3", now+3600, now+7200), auction("4", now-60, 0)},
+	}
+
+	server := stubNode(t, func(method string, params []json.RawMessage) interface{} {
+		switch method {
+		case "getAuctions":
+			var page int
+			require.Nil(t, json.Unmarshal(params[2], &page))
+			return resp.PaginatedResult[[]resp.AuctionResult]{Page: uint(page), TotalPages: uint(len(pages)), Result: pages[page-1]}
+		case "getToken":
+			return resp.TokenResult{Symbol: "SOUL", Decimals: 8}
+		}
+		t.Fatalf("unexpected method %s", method)
+		return nil
+	})
+	defer server.Close()
+
+	auctions, err := rpc.NewRPC(server.URL).GetActiveAuctions("main", "CROWN")
+	require.Nil(t, err)
+	require.Len(t, auctions, 2)
+	assert.Equal(t, "1", auctions[0].TokenID.String())
+	assert.Equal(t, "4", auctions[1].TokenID.String())
+	assert.Equal(t, "1.5", auctions[0].PriceDecimal)
+}
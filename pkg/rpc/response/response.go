@@ -428,6 +428,23 @@ func (s ScriptResult) DecodeResults(index int) *vm.VMObject {
 	return io.Deserialize[*vm.VMObject](decoded)
 }
 
+// UnmarshalResult decodes result, stored in .Result field, into Go value, see vm.Unmarshal
+func (s ScriptResult) UnmarshalResult(v interface{}) error {
+	decoded, err := hex.DecodeString(s.Result)
+	if err != nil {
+		return err
+	}
+
+	br := io.NewBinReaderFromBuf(decoded)
+	var obj vm.VMObject
+	obj.Deserialize(br)
+	if br.Err != nil {
+		return br.Err
+	}
+
+	return vm.Unmarshal(&obj, v)
+}
+
 // ArchiveResult comment
 type ArchiveResult struct {
 	Name          string   `json:"name"`
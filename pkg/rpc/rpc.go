@@ -1,8 +1,10 @@
 package rpc
 
 import (
+	"encoding/base64"
 	"fmt"
 	"math/big"
+	"strings"
 
 	"context"
 
@@ -80,6 +82,177 @@ func (rpc PhantasmaRPC) GetPlatforms() ([]resp.PlatformResult, error) {
 	return platforms, nil
 }
 
+// GetNexus returns nexus info including governance values, extended info includes token details
+func (rpc PhantasmaRPC) GetNexus(extended bool) (resp.NexusResult, error) {
+	var nexus resp.NexusResult
+	result, err := rpc.client.Call(context.Background(), "getNexus", extended)
+	if err != nil {
+		return nexus, err
+	}
+
+	if err := checkError(err, result.Error); err != nil {
+		return nexus, err
+	}
+
+	if err := result.GetObject(&nexus); err != nil {
+		return nexus, err
+	}
+
+	return nexus, nil
+}
+
+// GetOrganization returns organization by its ID, extended result includes members
+func (rpc PhantasmaRPC) GetOrganization(id string, extended bool) (resp.OrganizationResult, error) {
+	var organization resp.OrganizationResult
+	result, err := rpc.client.Call(context.Background(), "getOrganization", id, extended)
+	if err != nil {
+		return organization, err
+	}
+
+	if err := checkError(err, result.Error); err != nil {
+		return organization, err
+	}
+
+	if err := result.GetObject(&organization); err != nil {
+		return organization, err
+	}
+
+	return organization, nil
+}
+
+// GetOrganizationByName returns organization by its name, extended result includes members
+func (rpc PhantasmaRPC) GetOrganizationByName(name string, extended bool) (resp.OrganizationResult, error) {
+	var organization resp.OrganizationResult
+	result, err := rpc.client.Call(context.Background(), "getOrganizationByName", name, extended)
+	if err != nil {
+		return organization, err
+	}
+
+	if err := checkError(err, result.Error); err != nil {
+		return organization, err
+	}
+
+	if err := result.GetObject(&organization); err != nil {
+		return organization, err
+	}
+
+	return organization, nil
+}
+
+// GetOrganizations returns all organizations, extended results include members
+func (rpc PhantasmaRPC) GetOrganizations(extended bool) ([]resp.OrganizationResult, error) {
+	var organizations []resp.OrganizationResult
+	result, err := rpc.client.Call(context.Background(), "getOrganizations", extended)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkError(err, result.Error); err != nil {
+		return nil, err
+	}
+
+	if err := result.GetObject(&organizations); err != nil {
+		return nil, err
+	}
+
+	return organizations, nil
+}
+
+// GetValidators returns validators of the nexus
+func (rpc PhantasmaRPC) GetValidators() ([]resp.ValidatorResult, error) {
+	var validators []resp.ValidatorResult
+	result, err := rpc.client.Call(context.Background(), "getValidators")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkError(err, result.Error); err != nil {
+		return nil, err
+	}
+
+	if err := result.GetObject(&validators); err != nil {
+		return nil, err
+	}
+
+	return validators, nil
+}
+
+// GetLeaderboard returns rows of the leaderboard
+func (rpc PhantasmaRPC) GetLeaderboard(name string) (resp.LeaderboardResult, error) {
+	var leaderboard resp.LeaderboardResult
+	result, err := rpc.client.Call(context.Background(), "getLeaderboard", name)
+	if err != nil {
+		return leaderboard, err
+	}
+
+	if err := checkError(err, result.Error); err != nil {
+		return leaderboard, err
+	}
+
+	if err := result.GetObject(&leaderboard); err != nil {
+		return leaderboard, err
+	}
+
+	return leaderboard, nil
+}
+
+// GetArchive returns archive by its hash
+func (rpc PhantasmaRPC) GetArchive(hash string) (resp.ArchiveResult, error) {
+	var archive resp.ArchiveResult
+	result, err := rpc.client.Call(context.Background(), "getArchive", hash)
+	if err != nil {
+		return archive, err
+	}
+
+	if err := checkError(err, result.Error); err != nil {
+		return archive, err
+	}
+
+	if err := result.GetObject(&archive); err != nil {
+		return archive, err
+	}
+
+	return archive, nil
+}
+
+// WriteArchive uploads block of archive with the given index
+func (rpc PhantasmaRPC) WriteArchive(hash string, blockIndex int, content []byte) (bool, error) {
+	var written bool
+	result, err := rpc.client.Call(context.Background(), "writeArchive", hash, blockIndex, base64.StdEncoding.EncodeToString(content))
+	if err != nil {
+		return false, err
+	}
+
+	if err := checkError(err, result.Error); err != nil {
+		return false, err
+	}
+
+	if err := result.GetObject(&written); err != nil {
+		return false, err
+	}
+
+	return written, nil
+}
+
+// ReadArchive downloads block of archive with the given index
+func (rpc PhantasmaRPC) ReadArchive(hash string, blockIndex int) ([]byte, error) {
+	var content string
+	result, err := rpc.client.Call(context.Background(), "readArchive", hash, blockIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkError(err, result.Error); err != nil {
+		return nil, err
+	}
+
+	if err := result.GetObject(&content); err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(content)
+}
+
 // GetAccounts takes a comma separated list of addresses
 func (rpc PhantasmaRPC) GetAccounts(addresses string) ([]resp.AccountResult, error) {
 	var accounts []resp.AccountResult
@@ -242,8 +415,15 @@ func (rpc PhantasmaRPC) GetContract(name, chainName string) (resp.ContractResult
 
 // InvokeRawScript comment
 func (rpc PhantasmaRPC) InvokeRawScript(chain, script string) (resp.ScriptResult, error) {
+	return rpc.invokeRawScript(context.Background(), chain, script)
+}
+
+func (rpc PhantasmaRPC) invokeRawScript(ctx context.Context, chain, script string) (resp.ScriptResult, error) {
 	scriptResult := resp.ScriptResult{}
-	result, err := rpc.client.Call(context.Background(), "invokeRawScript", chain, script)
+	result, err := rpc.client.Call(ctx, "invokeRawScript", chain, script)
+	if err != nil {
+		return resp.ScriptResult{}, err
+	}
 
 	if err := checkError(err, result.Error); err != nil {
 		return resp.ScriptResult{}, err
@@ -346,6 +526,44 @@ func (rpc PhantasmaRPC) GetTokensAsMap(extended bool) (map[string]resp.TokenResu
 	return tokensMap, nil
 }
 
+// GetNFT returns data of NFT, extended data includes infusions and properties
+func (rpc PhantasmaRPC) GetNFT(symbol, tokenID string, extended bool) (resp.TokenDataResult, error) {
+	var nft resp.TokenDataResult
+	result, err := rpc.client.Call(context.Background(), "getNFT", symbol, tokenID, extended)
+	if err != nil {
+		return nft, err
+	}
+
+	if err := checkError(err, result.Error); err != nil {
+		return nft, err
+	}
+
+	if err := result.GetObject(&nft); err != nil {
+		return nft, err
+	}
+
+	return nft, nil
+}
+
+// GetNFTs returns data of several NFTs of the same token
+func (rpc PhantasmaRPC) GetNFTs(symbol string, tokenIDs []string, extended bool) ([]resp.TokenDataResult, error) {
+	var nfts []resp.TokenDataResult
+	result, err := rpc.client.Call(context.Background(), "getNFTs", symbol, strings.Join(tokenIDs, ","), extended)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkError(err, result.Error); err != nil {
+		return nil, err
+	}
+
+	if err := result.GetObject(&nfts); err != nil {
+		return nil, err
+	}
+
+	return nfts, nil
+}
+
 // GetToken comment
 func (rpc PhantasmaRPC) GetToken(symbol string, extended bool) (resp.TokenResult, error) {
 	var txResult resp.TokenResult
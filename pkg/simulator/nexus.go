@@ -0,0 +1,563 @@
+// Package simulator provides an in-memory Phantasma nexus for integration tests.
+// Transactions are executed locally by vm.VirtualMachine against simulated balances,
+// each transaction is minted into its own block, and a subset of the JSON-RPC API
+// is served by Server, so that rpc.PhantasmaRPC can be pointed at the simulator.
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/blockchain"
+	crypto "github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event"
+	energy "github.com/phantasma-io/phantasma-go/pkg/domain/stake"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/token"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/types"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	hashing "github.com/phantasma-io/phantasma-go/pkg/util/hashing"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+)
+
+const (
+	DefaultNexusName   = "simnet"
+	ChainName          = "main"
+	StakingTokenSymbol = "SOUL"
+	FuelTokenSymbol    = "KCAL"
+
+	// DefaultStakeLockTime is the time in seconds which should pass after staking before tokens can be unstaked
+	DefaultStakeLockTime = 86400
+	// DefaultStepLimit is the max number of instructions a single script can execute
+	DefaultStepLimit = 100000
+)
+
+var (
+	ErrInvalidNexus       = errors.New("invalid nexus name")
+	ErrInvalidChain       = errors.New("invalid chain name")
+	ErrExpired            = errors.New("transaction expired")
+	ErrDuplicate          = errors.New("transaction already exists")
+	ErrTokenExists        = errors.New("token already exists")
+	ErrTokenNotFound      = errors.New("token not found")
+	ErrInsufficientFunds  = errors.New("insufficient balance")
+	ErrNotWitness         = errors.New("witness failed")
+	ErrMaxSupplyExceeded  = errors.New("max supply exceeded")
+	ErrTransactionUnknown = errors.New("transaction not found")
+)
+
+// Stake describes SOUL staked by an address
+type Stake struct {
+	Amount *big.Int
+	Time   uint32
+	// Claims are parts of the stake generating KCAL since their claim dates
+	Claims []energy.EnergyClaim
+}
+
+func (s *Stake) clone() *Stake {
+	c := &Stake{Amount: new(big.Int).Set(s.Amount), Time: s.Time, Claims: make([]energy.EnergyClaim, len(s.Claims))}
+	for i, claim := range s.Claims {
+		c.Claims[i] = energy.EnergyClaim{
+			StakeAmount: new(big.Int).Set(claim.StakeAmount),
+			ClaimDate:   types.NewTimestamp(claim.ClaimDate.Value),
+			IsNew:       claim.IsNew,
+		}
+	}
+
+	return c
+}
+
+// ExecutionResult holds outcome of a script execution
+type ExecutionResult struct {
+	State vm.ExecutionState
+	// Err describes why execution faulted, nil for halted scripts
+	Err    error
+	Events []event.Event
+	// Results holds objects left on the stack, top of the stack first
+	Results []vm.VMObject
+	GasUsed uint64
+	// Fee is the amount of KCAL paid for the execution
+	Fee *big.Int
+}
+
+// TransactionRecord is a transaction included into a block
+type TransactionRecord struct {
+	ExecutionResult
+	Transaction blockchain.Transaction
+	BlockHeight uint
+	BlockHash   crypto.Hash
+	Timestamp   uint32
+}
+
+// Block is a simulated block, every block contains a single transaction
+type Block struct {
+	Height       uint
+	Hash         crypto.Hash
+	PreviousHash crypto.Hash
+	Timestamp    uint32
+	Transactions []*TransactionRecord
+}
+
+// ledger holds state which is changed by scripts, it's copied before execution
+// and committed only if the script halts
+type ledger struct {
+	balances map[string]map[string]*big.Int
+	supplies map[string]*big.Int
+	stakes   map[string]*Stake
+}
+
+func newLedger() *ledger {
+	return &ledger{
+		balances: make(map[string]map[string]*big.Int),
+		supplies: make(map[string]*big.Int),
+		stakes:   make(map[string]*Stake),
+	}
+}
+
+func (l *ledger) clone() *ledger {
+	c := newLedger()
+	for symbol, balances := range l.balances {
+		c.balances[symbol] = make(map[string]*big.Int, len(balances))
+		for address, amount := range balances {
+			c.balances[symbol][address] = new(big.Int).Set(amount)
+		}
+	}
+	for symbol, supply := range l.supplies {
+		c.supplies[symbol] = new(big.Int).Set(supply)
+	}
+	for address, stake := range l.stakes {
+		c.stakes[address] = stake.clone()
+	}
+
+	return c
+}
+
+func (l *ledger) balance(symbol string, address crypto.Address) *big.Int {
+	if amount, ok := l.balances[symbol][address.String()]; ok {
+		return new(big.Int).Set(amount)
+	}
+
+	return big.NewInt(0)
+}
+
+func (l *ledger) setBalance(symbol string, address crypto.Address, amount *big.Int) {
+	if _, ok := l.balances[symbol]; !ok {
+		l.balances[symbol] = make(map[string]*big.Int)
+	}
+
+	if amount.Sign() == 0 {
+		delete(l.balances[symbol], address.String())
+	} else {
+		l.balances[symbol][address.String()] = amount
+	}
+}
+
+func (l *ledger) supply(symbol string) *big.Int {
+	if supply, ok := l.supplies[symbol]; ok {
+		return new(big.Int).Set(supply)
+	}
+
+	return big.NewInt(0)
+}
+
+// move transfers tokens between addresses, without emitting any events
+func (l *ledger) move(symbol string, from, to crypto.Address, amount *big.Int) error {
+	balance := l.balance(symbol, from)
+	if balance.Cmp(amount) < 0 {
+		return fmt.Errorf("%w: %s has %s %s, %s required", ErrInsufficientFunds, from.String(), balance.String(), symbol, amount.String())
+	}
+
+	l.setBalance(symbol, from, balance.Sub(balance, amount))
+	l.setBalance(symbol, to, l.balance(symbol, to).Add(l.balance(symbol, to), amount))
+	return nil
+}
+
+// mint creates new tokens, checking max supply of finite tokens
+func (l *ledger) mint(info *token.TokenInfo, to crypto.Address, amount *big.Int) error {
+	supply := l.supply(info.Symbol)
+	supply.Add(supply, amount)
+
+	if info.Flags&token.Finite != 0 && supply.Cmp(info.MaxSupply) > 0 {
+		return fmt.Errorf("%w: %s max supply is %s", ErrMaxSupplyExceeded, info.Symbol, info.MaxSupply.String())
+	}
+
+	l.supplies[info.Symbol] = supply
+	l.setBalance(info.Symbol, to, l.balance(info.Symbol, to).Add(l.balance(info.Symbol, to), amount))
+	return nil
+}
+
+// Nexus is an in-memory simulated Phantasma nexus with a single main chain.
+// It is safe for concurrent use.
+type Nexus struct {
+	Name string
+	// MinimumStake is the minimal amount of SOUL an address can have staked
+	MinimumStake *big.Int
+	// StakeLockTime is the time in seconds which should pass after staking before unstaking is allowed
+	StakeLockTime uint32
+	// StepLimit is the max number of instructions a single script can execute
+	StepLimit int
+
+	mu         sync.Mutex
+	time       uint32
+	tokens     map[string]*token.TokenInfo
+	state      *ledger
+	blocks     []*Block
+	txs        map[string]*TransactionRecord
+	addressTxs map[string][]*TransactionRecord
+}
+
+// NewNexus creates a simulated nexus with SOUL and KCAL tokens and no balances.
+// Simulated time starts at the current time and only changes with AdvanceTime().
+func NewNexus() *Nexus {
+	n := &Nexus{
+		Name:          DefaultNexusName,
+		MinimumStake:  big.NewInt(100000000), // 1 SOUL
+		StakeLockTime: DefaultStakeLockTime,
+		StepLimit:     DefaultStepLimit,
+		time:          uint32(time.Now().Unix()),
+		tokens:        make(map[string]*token.TokenInfo),
+		state:         newLedger(),
+		txs:           make(map[string]*TransactionRecord),
+		addressTxs:    make(map[string][]*TransactionRecord),
+	}
+
+	n.tokens[StakingTokenSymbol] = &token.TokenInfo{
+		Symbol:    StakingTokenSymbol,
+		Name:      "Phantasma Stake",
+		Owner:     crypto.NullAddress(),
+		Flags:     token.Transferable | token.Fungible | token.Divisible | token.Stakable,
+		MaxSupply: big.NewInt(0),
+		Decimals:  8,
+	}
+	n.tokens[FuelTokenSymbol] = &token.TokenInfo{
+		Symbol:    FuelTokenSymbol,
+		Name:      "Phantasma Energy",
+		Owner:     crypto.NullAddress(),
+		Flags:     token.Transferable | token.Fungible | token.Divisible | token.Fuel | token.Burnable,
+		MaxSupply: big.NewInt(0),
+		Decimals:  10,
+	}
+
+	return n
+}
+
+// ContractAddress returns address of a contract or a chain with the given name, same way node derives it
+func ContractAddress(name string) crypto.Address {
+	data := make([]byte, crypto.Length)
+	data[0] = byte(crypto.System)
+	copy(data[2:], hashing.Sha256([]byte(name)))
+	return crypto.NewAddress(data)
+}
+
+// Time returns current simulated time
+func (n *Nexus) Time() uint32 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.time
+}
+
+// AdvanceTime moves simulated time forward
+func (n *Nexus) AdvanceTime(seconds uint32) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.time += seconds
+}
+
+// CreateToken registers a new token
+func (n *Nexus) CreateToken(info token.TokenInfo) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.tokens[info.Symbol]; ok {
+		return fmt.Errorf("%w: %s", ErrTokenExists, info.Symbol)
+	}
+
+	if info.MaxSupply == nil {
+		info.MaxSupply = big.NewInt(0)
+	}
+
+	n.tokens[info.Symbol] = &info
+	return nil
+}
+
+// Token returns information about a token
+func (n *Nexus) Token(symbol string) (token.TokenInfo, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	info, ok := n.tokens[symbol]
+	if !ok {
+		return token.TokenInfo{}, false
+	}
+
+	return *info, true
+}
+
+// Tokens returns all registered tokens, ordered by symbol
+func (n *Nexus) Tokens() []token.TokenInfo {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.sortedTokens()
+}
+
+func (n *Nexus) sortedTokens() []token.TokenInfo {
+	result := make([]token.TokenInfo, 0, len(n.tokens))
+	for _, info := range n.tokens {
+		result = append(result, *info)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Symbol < result[j].Symbol })
+	return result
+}
+
+// Mint credits tokens to an address directly, bypassing scripts and witness checks.
+// It's meant to fund test accounts, no transaction or events are created.
+func (n *Nexus) Mint(symbol string, to crypto.Address, amount *big.Int) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	info, ok := n.tokens[symbol]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrTokenNotFound, symbol)
+	}
+
+	return n.state.mint(info, to, amount)
+}
+
+// BalanceOf returns token balance of an address
+func (n *Nexus) BalanceOf(symbol string, address crypto.Address) *big.Int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.state.balance(symbol, address)
+}
+
+// Supply returns current supply of a token
+func (n *Nexus) Supply(symbol string) *big.Int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.state.supply(symbol)
+}
+
+// StakeOf returns SOUL staked by an address
+func (n *Nexus) StakeOf(address crypto.Address) Stake {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.state.stakeOf(address)
+}
+
+// UnclaimedOf returns KCAL generated by SOUL staked by an address which can be claimed
+func (n *Nexus) UnclaimedOf(address crypto.Address) *big.Int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.calculator().Unclaimed(n.state.stakeOf(address).Claims, types.Timestamp{Value: n.time})
+}
+
+// calculator returns KCAL calculator using mainnet rules and nexus lock time
+func (n *Nexus) calculator() energy.Calculator {
+	c := energy.NewCalculator()
+	c.LockTime = n.StakeLockTime
+	return c
+}
+
+func (l *ledger) stakeOf(address crypto.Address) Stake {
+	if stake, ok := l.stakes[address.String()]; ok {
+		return *stake.clone()
+	}
+
+	return Stake{Amount: big.NewInt(0), Claims: []energy.EnergyClaim{}}
+}
+
+// Height returns number of blocks minted so far
+func (n *Nexus) Height() uint {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return uint(len(n.blocks))
+}
+
+// Block returns block at given height, first block has height 1
+func (n *Nexus) Block(height uint) (*Block, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if height == 0 || height > uint(len(n.blocks)) {
+		return nil, false
+	}
+
+	return n.blocks[height-1], true
+}
+
+// Transaction returns transaction record by transaction hash
+func (n *Nexus) Transaction(hash crypto.Hash) (*TransactionRecord, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	record, ok := n.txs[hash.String()]
+	return record, ok
+}
+
+// AddressTransactions returns transactions which emitted events for the address, newest first
+func (n *Nexus) AddressTransactions(address crypto.Address) []*TransactionRecord {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	records := n.addressTxs[address.String()]
+	result := make([]*TransactionRecord, len(records))
+	for i, record := range records {
+		result[len(records)-1-i] = record
+	}
+
+	return result
+}
+
+// SendTransaction executes a transaction and mints it into a new block.
+// An error is returned if transaction can't be accepted, faulted transactions are
+// included into the chain with their state changes reverted, except for the gas used up to the fault.
+func (n *Nexus) SendTransaction(tx blockchain.Transaction) (*TransactionRecord, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if tx.NexusName != n.Name {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidNexus, tx.NexusName)
+	}
+
+	if tx.ChainName != ChainName {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidChain, tx.ChainName)
+	}
+
+	if tx.Expiration < n.time {
+		return nil, ErrExpired
+	}
+
+	if _, ok := n.txs[tx.Hash.String()]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrDuplicate, tx.Hash.String())
+	}
+
+	record := &TransactionRecord{
+		ExecutionResult: n.execute(tx.Script, newRuntime(n, n.state.clone(), &tx), true),
+		Transaction:     tx,
+		Timestamp:       n.time,
+	}
+
+	block := &Block{
+		Height:       uint(len(n.blocks)) + 1,
+		Timestamp:    n.time,
+		Transactions: []*TransactionRecord{record},
+	}
+	if len(n.blocks) > 0 {
+		block.PreviousHash = n.blocks[len(n.blocks)-1].Hash
+	}
+
+	bw := io.NewBufBinWriter()
+	bw.WriteU32LE(uint32(block.Height))
+	bw.WriteU32LE(block.Timestamp)
+	bw.WriteBytes(block.PreviousHash.Bytes())
+	bw.WriteBytes(tx.Hash.Bytes())
+	block.Hash, _ = crypto.HashFromBytes(hashing.Sha256(bw.Bytes()))
+
+	record.BlockHeight = block.Height
+	record.BlockHash = block.Hash
+
+	n.blocks = append(n.blocks, block)
+	n.txs[tx.Hash.String()] = record
+
+	seen := make(map[string]bool)
+	for _, e := range record.Events {
+		address := e.Address.String()
+		if !seen[address] {
+			seen[address] = true
+			n.addressTxs[address] = append(n.addressTxs[address], record)
+		}
+	}
+
+	return record, nil
+}
+
+// InvokeScript executes a script without changing the state of the nexus, same way
+// node executes scripts passed to invokeRawScript. Script has no witnesses.
+func (n *Nexus) InvokeScript(script []byte) ExecutionResult {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.execute(script, newRuntime(n, n.state.clone(), nil), false)
+}
+
+// MeasureGas executes a script without changing the state of the nexus and returns gas it used.
+// Witness checks are skipped, so transaction scripts can be measured before they are signed.
+func (n *Nexus) MeasureGas(chain string, script []byte) (*big.Int, error) {
+	if chain != ChainName {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidChain, chain)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	r := newRuntime(n, n.state.clone(), nil)
+	r.skipWitness = true
+
+	result := n.execute(script, r, false)
+	if result.State != vm.Halt {
+		if result.Err == nil {
+			return nil, fmt.Errorf("script execution failed: %s", result.State)
+		}
+		return nil, result.Err
+	}
+
+	return new(big.Int).SetUint64(result.GasUsed), nil
+}
+
+// execute runs script against the ledger copy held by runtime, committing it if requested and script halts
+func (n *Nexus) execute(script []byte, r *runtime, commit bool) ExecutionResult {
+	machine := vm.NewVirtualMachine(script)
+	machine.StepLimit = n.StepLimit
+	r.register(machine)
+
+	state, err := machine.Execute()
+	if err == nil {
+		err = r.settleGas(machine)
+		if err != nil {
+			state = vm.Fault
+		}
+	}
+
+	result := ExecutionResult{
+		State:   state,
+		Err:     err,
+		GasUsed: machine.GasUsed,
+		Fee:     big.NewInt(0),
+		Events:  []event.Event{},
+		Results: []vm.VMObject{},
+	}
+
+	if state != vm.Halt {
+		if commit && r.gasAllowed && r.chargeFault(n.state.clone(), machine) == nil {
+			n.state = r.ledger
+			result.Events = r.events
+			result.Fee = r.fee
+		}
+		return result
+	}
+
+	result.Events = r.events
+	result.Fee = r.fee
+
+	stack := machine.Stack()
+	for i := len(stack) - 1; i >= 0; i-- {
+		result.Results = append(result.Results, stack[i])
+	}
+
+	if commit {
+		n.state = r.ledger
+	}
+
+	return result
+}
@@ -0,0 +1,524 @@
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/phantasma-io/phantasma-go/pkg/blockchain"
+	crypto "github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event"
+	energy "github.com/phantasma-io/phantasma-go/pkg/domain/stake"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/token"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/types"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+)
+
+// runtime holds state of a single script execution
+type runtime struct {
+	nexus  *Nexus
+	ledger *ledger
+	tx     *blockchain.Transaction
+	events []event.Event
+
+	// skipWitness makes every witness check pass, it's used to measure gas of unsigned scripts
+	skipWitness bool
+
+	gasAllowed bool
+	gasSpent   bool
+	gasPayer   crypto.Address
+	gasTarget  crypto.Address
+	gasPrice   *big.Int
+	gasLimit   *big.Int
+	fee        *big.Int
+}
+
+func newRuntime(n *Nexus, l *ledger, tx *blockchain.Transaction) *runtime {
+	return &runtime{
+		nexus:  n,
+		ledger: l,
+		tx:     tx,
+		events: []event.Event{},
+		fee:    big.NewInt(0),
+	}
+}
+
+func (r *runtime) register(machine *vm.VirtualMachine) {
+	machine.RegisterInterop("Runtime.TransferTokens", r.transferTokens)
+	machine.RegisterInterop("Runtime.MintTokens", r.mintTokens)
+	machine.RegisterInterop("Runtime.Time", r.time)
+
+	machine.RegisterContext(vm.NewNativeContext("stake", map[string]vm.InteropHandler{
+		"Stake":                r.stake,
+		"Unstake":              r.unstake,
+		"Claim":                r.claim,
+		"GetStake":             r.getStake,
+		"GetUnclaimed":         r.getUnclaimed,
+		"GetTimeBeforeUnstake": r.getTimeBeforeUnstake,
+	}))
+
+	machine.RegisterContext(vm.NewNativeContext("gas", map[string]vm.InteropHandler{
+		"AllowGas": r.allowGas,
+		"SpendGas": r.spendGas,
+	}))
+}
+
+// isWitness checks if transaction was signed by the address
+func (r *runtime) isWitness(address crypto.Address) bool {
+	return r.skipWitness || (r.tx != nil && r.tx.IsSignedBy([]crypto.Address{address}))
+}
+
+func (r *runtime) expectWitness(address crypto.Address) error {
+	if !r.isWitness(address) {
+		return fmt.Errorf("%w: %s", ErrNotWitness, address.String())
+	}
+
+	return nil
+}
+
+func (r *runtime) notify(machine *vm.VirtualMachine, kind event.EventKind, address crypto.Address, data []byte) {
+	r.events = append(r.events, event.Event{
+		Kind:     kind,
+		Address:  address,
+		Contract: machine.CurrentContext().Name(),
+		Data:     data,
+	})
+}
+
+func (r *runtime) notifyToken(machine *vm.VirtualMachine, kind event.EventKind, address crypto.Address, symbol string, amount *big.Int) {
+	data := event.TokenEventData{Symbol: symbol, Value: amount, ChainName: ChainName}
+	r.notify(machine, kind, address, io.Serialize(&data))
+}
+
+// notifyGas emits gas event, these are always attributed to gas contract since payment
+// can be settled after the script finished
+func (r *runtime) notifyGas(kind event.EventKind, address crypto.Address, amount *big.Int) {
+	data := event.GasEventData{Address: r.gasTarget, Price: r.gasPrice, Amount: amount}
+	r.events = append(r.events, event.Event{Kind: kind, Address: address, Contract: "gas", Data: io.Serialize(&data)})
+}
+
+func (r *runtime) fungibleToken(symbol string) (*token.TokenInfo, error) {
+	info, ok := r.nexus.tokens[symbol]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTokenNotFound, symbol)
+	}
+
+	if info.Flags&token.Fungible == 0 {
+		return nil, fmt.Errorf("token %s is not fungible, NFTs are not supported by simulator", symbol)
+	}
+
+	return info, nil
+}
+
+func popAmount(machine *vm.VirtualMachine) (*big.Int, error) {
+	amount, err := machine.PopNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	if amount.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid amount %s", amount.String())
+	}
+
+	return amount, nil
+}
+
+// transferTokens implements Runtime.TransferTokens(from, to, symbol, amount)
+func (r *runtime) transferTokens(machine *vm.VirtualMachine) error {
+	from, err := machine.PopAddress()
+	if err != nil {
+		return err
+	}
+	to, err := machine.PopAddress()
+	if err != nil {
+		return err
+	}
+	symbol, err := machine.PopString()
+	if err != nil {
+		return err
+	}
+	amount, err := popAmount(machine)
+	if err != nil {
+		return err
+	}
+
+	info, err := r.fungibleToken(symbol)
+	if err != nil {
+		return err
+	}
+
+	if info.Flags&token.Transferable == 0 {
+		return fmt.Errorf("token %s is not transferable", symbol)
+	}
+
+	if from.String() == to.String() {
+		return errors.New("source and destination addresses must be different")
+	}
+
+	if err := r.expectWitness(from); err != nil {
+		return err
+	}
+
+	if err := r.ledger.move(symbol, from, to, amount); err != nil {
+		return err
+	}
+
+	r.notifyToken(machine, event.TokenSend, from, symbol, amount)
+	r.notifyToken(machine, event.TokenReceive, to, symbol, amount)
+	return nil
+}
+
+// mintTokens implements Runtime.MintTokens(from, to, symbol, amount), from has to be the token owner
+func (r *runtime) mintTokens(machine *vm.VirtualMachine) error {
+	from, err := machine.PopAddress()
+	if err != nil {
+		return err
+	}
+	to, err := machine.PopAddress()
+	if err != nil {
+		return err
+	}
+	symbol, err := machine.PopString()
+	if err != nil {
+		return err
+	}
+	amount, err := popAmount(machine)
+	if err != nil {
+		return err
+	}
+
+	info, err := r.fungibleToken(symbol)
+	if err != nil {
+		return err
+	}
+
+	if info.Owner.String() != from.String() {
+		return fmt.Errorf("%s is not the owner of %s", from.String(), symbol)
+	}
+
+	if err := r.expectWitness(from); err != nil {
+		return err
+	}
+
+	if err := r.ledger.mint(info, to, amount); err != nil {
+		return err
+	}
+
+	r.notifyToken(machine, event.TokenMint, to, symbol, amount)
+	return nil
+}
+
+// time implements Runtime.Time()
+func (r *runtime) time(machine *vm.VirtualMachine) error {
+	machine.Push(vm.NewTimestamp(types.Timestamp{Value: r.nexus.time}))
+	return nil
+}
+
+// stake implements stake.Stake(from, amount)
+func (r *runtime) stake(machine *vm.VirtualMachine) error {
+	from, err := machine.PopAddress()
+	if err != nil {
+		return err
+	}
+	amount, err := popAmount(machine)
+	if err != nil {
+		return err
+	}
+
+	if err := r.expectWitness(from); err != nil {
+		return err
+	}
+
+	current := r.ledger.stakeOf(from)
+	total := new(big.Int).Add(current.Amount, amount)
+	if total.Cmp(r.nexus.MinimumStake) < 0 {
+		return fmt.Errorf("stake must be at least %s", r.nexus.MinimumStake.String())
+	}
+
+	stakeAddress := ContractAddress("stake")
+	if err := r.ledger.move(StakingTokenSymbol, from, stakeAddress, amount); err != nil {
+		return err
+	}
+
+	claims := append(current.Claims, energy.EnergyClaim{StakeAmount: amount, ClaimDate: types.NewTimestamp(r.nexus.time), IsNew: true})
+	r.ledger.stakes[from.String()] = &Stake{Amount: total, Time: r.nexus.time, Claims: claims}
+
+	r.notifyToken(machine, event.TokenSend, from, StakingTokenSymbol, amount)
+	r.notifyToken(machine, event.TokenReceive, stakeAddress, StakingTokenSymbol, amount)
+	r.notifyToken(machine, event.TokenStake, from, StakingTokenSymbol, amount)
+	return nil
+}
+
+// unstake implements stake.Unstake(from, amount)
+func (r *runtime) unstake(machine *vm.VirtualMachine) error {
+	from, err := machine.PopAddress()
+	if err != nil {
+		return err
+	}
+	amount, err := popAmount(machine)
+	if err != nil {
+		return err
+	}
+
+	if err := r.expectWitness(from); err != nil {
+		return err
+	}
+
+	current := r.ledger.stakeOf(from)
+	if current.Amount.Cmp(amount) < 0 {
+		return fmt.Errorf("%w: %s has %s staked", ErrInsufficientFunds, from.String(), current.Amount.String())
+	}
+
+	if r.nexus.time < current.Time+r.nexus.StakeLockTime {
+		return fmt.Errorf("waiting period required, tokens can be unstaked after %d", current.Time+r.nexus.StakeLockTime)
+	}
+
+	left := new(big.Int).Sub(current.Amount, amount)
+	if left.Sign() > 0 && left.Cmp(r.nexus.MinimumStake) < 0 {
+		return fmt.Errorf("leftover stake must be zero or at least %s", r.nexus.MinimumStake.String())
+	}
+
+	stakeAddress := ContractAddress("stake")
+	if err := r.ledger.move(StakingTokenSymbol, stakeAddress, from, amount); err != nil {
+		return err
+	}
+
+	if left.Sign() == 0 {
+		delete(r.ledger.stakes, from.String())
+	} else {
+		r.ledger.stakes[from.String()] = &Stake{Amount: left, Time: current.Time, Claims: reduceClaims(current.Claims, amount)}
+	}
+
+	r.notifyToken(machine, event.TokenSend, stakeAddress, StakingTokenSymbol, amount)
+	r.notifyToken(machine, event.TokenReceive, from, StakingTokenSymbol, amount)
+	r.notifyToken(machine, event.TokenClaim, from, StakingTokenSymbol, amount)
+	return nil
+}
+
+// reduceClaims removes unstaked amount from claims, newest claims are reduced first
+func reduceClaims(claims []energy.EnergyClaim, amount *big.Int) []energy.EnergyClaim {
+	left := new(big.Int).Set(amount)
+	for i := len(claims) - 1; i >= 0 && left.Sign() > 0; i-- {
+		if claims[i].StakeAmount.Cmp(left) <= 0 {
+			left.Sub(left, claims[i].StakeAmount)
+			claims = claims[:i]
+			continue
+		}
+
+		claims[i].StakeAmount = new(big.Int).Sub(claims[i].StakeAmount, left)
+		left.SetInt64(0)
+	}
+
+	return claims
+}
+
+// claim implements stake.Claim(from, stakeAddress), KCAL generated by stake of stakeAddress is minted to from
+// and claim dates are moved by the number of claimed periods
+func (r *runtime) claim(machine *vm.VirtualMachine) error {
+	from, err := machine.PopAddress()
+	if err != nil {
+		return err
+	}
+	stakeAddress, err := machine.PopAddress()
+	if err != nil {
+		return err
+	}
+
+	if err := r.expectWitness(from); err != nil {
+		return err
+	}
+
+	stake, ok := r.ledger.stakes[stakeAddress.String()]
+	if !ok {
+		return fmt.Errorf("%s has nothing staked", stakeAddress.String())
+	}
+
+	calc := r.nexus.calculator()
+	now := types.Timestamp{Value: r.nexus.time}
+	unclaimed := calc.Unclaimed(stake.Claims, now)
+	if unclaimed.Sign() == 0 {
+		return fmt.Errorf("nothing to claim for %s", stakeAddress.String())
+	}
+
+	info, err := r.fungibleToken(FuelTokenSymbol)
+	if err != nil {
+		return err
+	}
+	if err := r.ledger.mint(info, from, unclaimed); err != nil {
+		return err
+	}
+
+	for i := range stake.Claims {
+		claimDate := stake.Claims[i].ClaimDate.Value
+		periods := (now.Value - claimDate) / calc.ClaimPeriod
+		stake.Claims[i].ClaimDate = types.NewTimestamp(claimDate + periods*calc.ClaimPeriod)
+		stake.Claims[i].IsNew = false
+	}
+
+	r.notifyToken(machine, event.TokenMint, from, FuelTokenSymbol, unclaimed)
+	return nil
+}
+
+// getUnclaimed implements stake.GetUnclaimed(address)
+func (r *runtime) getUnclaimed(machine *vm.VirtualMachine) error {
+	address, err := machine.PopAddress()
+	if err != nil {
+		return err
+	}
+
+	unclaimed := r.nexus.calculator().Unclaimed(r.ledger.stakeOf(address).Claims, types.Timestamp{Value: r.nexus.time})
+	machine.Push(vm.NewNumber(unclaimed))
+	return nil
+}
+
+// getTimeBeforeUnstake implements stake.GetTimeBeforeUnstake(address), it returns number of seconds left
+func (r *runtime) getTimeBeforeUnstake(machine *vm.VirtualMachine) error {
+	address, err := machine.PopAddress()
+	if err != nil {
+		return err
+	}
+
+	left := int64(0)
+	stake := r.ledger.stakeOf(address)
+	if stake.Amount.Sign() > 0 {
+		left = max(int64(stake.Time)+int64(r.nexus.StakeLockTime)-int64(r.nexus.time), 0)
+	}
+
+	machine.Push(vm.NewNumber(big.NewInt(left)))
+	return nil
+}
+
+// getStake implements stake.GetStake(address)
+func (r *runtime) getStake(machine *vm.VirtualMachine) error {
+	address, err := machine.PopAddress()
+	if err != nil {
+		return err
+	}
+
+	machine.Push(vm.NewNumber(r.ledger.stakeOf(address).Amount))
+	return nil
+}
+
+// allowGas implements gas.AllowGas(from, target, price, limit).
+// Max fee is moved to escrow and machine gas limit is set to the given limit.
+func (r *runtime) allowGas(machine *vm.VirtualMachine) error {
+	from, err := machine.PopAddress()
+	if err != nil {
+		return err
+	}
+	target, err := machine.PopAddress()
+	if err != nil {
+		return err
+	}
+	price, err := popAmount(machine)
+	if err != nil {
+		return err
+	}
+	limit, err := popAmount(machine)
+	if err != nil {
+		return err
+	}
+
+	if r.gasAllowed {
+		return errors.New("gas already allowed")
+	}
+
+	if err := r.expectWitness(from); err != nil {
+		return err
+	}
+
+	if !limit.IsUint64() {
+		return fmt.Errorf("invalid gas limit %s", limit.String())
+	}
+
+	escrow := new(big.Int).Mul(price, limit)
+	if err := r.ledger.move(FuelTokenSymbol, from, ContractAddress("gas"), escrow); err != nil {
+		return err
+	}
+
+	r.gasAllowed = true
+	r.gasPayer = from
+	r.gasTarget = target
+	r.gasPrice = price
+	r.gasLimit = limit
+	machine.GasLimit = limit.Uint64()
+
+	r.notifyGas(event.GasEscrow, from, limit)
+	return nil
+}
+
+// spendGas implements gas.SpendGas(from)
+func (r *runtime) spendGas(machine *vm.VirtualMachine) error {
+	from, err := machine.PopAddress()
+	if err != nil {
+		return err
+	}
+
+	if !r.gasAllowed {
+		return errors.New("gas not allowed")
+	}
+
+	if from.String() != r.gasPayer.String() {
+		return fmt.Errorf("gas was allowed by %s", r.gasPayer.String())
+	}
+
+	return r.payGas(machine)
+}
+
+// settleGas pays for gas if script allowed gas but didn't call SpendGas
+func (r *runtime) settleGas(machine *vm.VirtualMachine) error {
+	if !r.gasAllowed || r.gasSpent {
+		return nil
+	}
+
+	return r.payGas(machine)
+}
+
+func (r *runtime) payGas(machine *vm.VirtualMachine) error {
+	if r.gasSpent {
+		return errors.New("gas already spent")
+	}
+
+	used := r.usedGas(machine)
+	r.fee = new(big.Int).Mul(r.gasPrice, used)
+	refund := new(big.Int).Mul(r.gasPrice, r.gasLimit)
+	refund.Sub(refund, r.fee)
+
+	if refund.Sign() > 0 {
+		if err := r.ledger.move(FuelTokenSymbol, ContractAddress("gas"), r.gasPayer, refund); err != nil {
+			return err
+		}
+	}
+
+	r.gasSpent = true
+	r.notifyGas(event.GasPayment, r.gasPayer, used)
+	return nil
+}
+
+// chargeFault charges gas used up to the fault of a script to the payer on top of the given ledger.
+// Other state changes and events of the script are discarded, same as on the node.
+func (r *runtime) chargeFault(l *ledger, machine *vm.VirtualMachine) error {
+	used := r.usedGas(machine)
+	fee := new(big.Int).Mul(r.gasPrice, used)
+	if err := l.move(FuelTokenSymbol, r.gasPayer, ContractAddress("gas"), fee); err != nil {
+		return err
+	}
+
+	r.ledger = l
+	r.fee = fee
+	r.events = []event.Event{}
+	r.notifyGas(event.GasEscrow, r.gasPayer, r.gasLimit)
+	r.notifyGas(event.GasPayment, r.gasPayer, used)
+	return nil
+}
+
+// usedGas returns gas used by the machine, capped by the allowed gas limit
+func (r *runtime) usedGas(machine *vm.VirtualMachine) *big.Int {
+	used := new(big.Int).SetUint64(machine.GasUsed)
+	if used.Cmp(r.gasLimit) > 0 {
+		used.Set(r.gasLimit)
+	}
+
+	return used
+}
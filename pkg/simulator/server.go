@@ -0,0 +1,520 @@
+package simulator
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/phantasma-io/phantasma-go/pkg/blockchain"
+	crypto "github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/token"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/types"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	"github.com/phantasma-io/phantasma-go/pkg/jsonrpc"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+)
+
+const (
+	codeParseError     = -32700
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeServerError    = -32000
+)
+
+type rpcRequest struct {
+	JSONRPC string            `json:"jsonrpc"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+	ID      json.RawMessage   `json:"id"`
+}
+
+type rpcMethod func(s *Server, params []json.RawMessage) (interface{}, error)
+
+// Server serves a subset of Phantasma JSON-RPC API using a simulated nexus:
+// getAccount, getAccounts, getBlockHeight, getBlockByHeight, getTransaction,
+// getAddressTransactions, getAddressTransactionCount, getToken, getTokens,
+// invokeRawScript and sendRawTransaction.
+//
+// Server implements http.Handler, so it can be used with httptest.NewServer()
+// and rpc.NewRPC(server.URL).
+type Server struct {
+	Nexus *Nexus
+}
+
+// NewServer creates a JSON-RPC server for the nexus
+func NewServer(nexus *Nexus) *Server {
+	return &Server{Nexus: nexus}
+}
+
+var rpcMethods = map[string]rpcMethod{
+	"getAccount":                 (*Server).getAccount,
+	"getAccounts":                (*Server).getAccounts,
+	"getBlockHeight":             (*Server).getBlockHeight,
+	"getBlockByHeight":           (*Server).getBlockByHeight,
+	"getTransaction":             (*Server).getTransaction,
+	"getAddressTransactions":     (*Server).getAddressTransactions,
+	"getAddressTransactionCount": (*Server).getAddressTransactionCount,
+	"getToken":                   (*Server).getToken,
+	"getTokens":                  (*Server).getTokens,
+	"invokeRawScript":            (*Server).invokeRawScript,
+	"sendRawTransaction":         (*Server).sendRawTransaction,
+}
+
+// ServeHTTP handles single and batch JSON-RPC requests
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body bytes.Buffer
+	if _, err := body.ReadFrom(r.Body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var result interface{}
+	data := bytes.TrimSpace(body.Bytes())
+
+	if len(data) > 0 && data[0] == '[' {
+		var requests []rpcRequest
+		if err := json.Unmarshal(data, &requests); err != nil {
+			result = errorResponse(0, codeParseError, err.Error())
+		} else {
+			responses := make([]*jsonrpc.RPCResponse, len(requests))
+			for i := range requests {
+				responses[i] = s.handle(&requests[i])
+			}
+			result = responses
+		}
+	} else {
+		var request rpcRequest
+		if err := json.Unmarshal(data, &request); err != nil {
+			result = errorResponse(0, codeParseError, err.Error())
+		} else {
+			result = s.handle(&request)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *Server) handle(request *rpcRequest) *jsonrpc.RPCResponse {
+	id := parseID(request.ID)
+
+	method, ok := rpcMethods[request.Method]
+	if !ok {
+		return errorResponse(id, codeMethodNotFound, "method not found: "+request.Method)
+	}
+
+	result, err := method(s, request.Params)
+	if err != nil {
+		code := codeServerError
+		if _, ok := err.(*paramsError); ok {
+			code = codeInvalidParams
+		}
+		return errorResponse(id, code, err.Error())
+	}
+
+	return &jsonrpc.RPCResponse{JSONRPC: "2.0", Result: result, ID: id}
+}
+
+// parseID converts request id to a number, client sends ids as strings but expects numbers in responses
+func parseID(raw json.RawMessage) int {
+	var n int
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return n
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		n, _ = strconv.Atoi(s)
+	}
+
+	return n
+}
+
+func errorResponse(id, code int, message string) *jsonrpc.RPCResponse {
+	return &jsonrpc.RPCResponse{JSONRPC: "2.0", Error: &jsonrpc.RPCError{Code: code, Message: message}, ID: id}
+}
+
+type paramsError struct {
+	message string
+}
+
+func (e *paramsError) Error() string {
+	return e.message
+}
+
+// decodeParams unmarshals positional params into targets, missing trailing params keep their values
+func decodeParams(params []json.RawMessage, required int, targets ...interface{}) error {
+	if len(params) < required {
+		return &paramsError{fmt.Sprintf("expected at least %d params, got %d", required, len(params))}
+	}
+
+	for i, target := range targets {
+		if i >= len(params) {
+			break
+		}
+
+		if err := json.Unmarshal(params[i], target); err != nil {
+			return &paramsError{fmt.Sprintf("invalid param %d: %s", i, err.Error())}
+		}
+	}
+
+	return nil
+}
+
+func decodeAddress(text string) (crypto.Address, error) {
+	address, err := crypto.FromString(text)
+	if err != nil {
+		return crypto.Address{}, &paramsError{fmt.Sprintf("invalid address %s: %s", text, err.Error())}
+	}
+
+	return address, nil
+}
+
+func (s *Server) getAccount(params []json.RawMessage) (interface{}, error) {
+	var text string
+	if err := decodeParams(params, 1, &text); err != nil {
+		return nil, err
+	}
+
+	address, err := decodeAddress(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.accountResult(address), nil
+}
+
+func (s *Server) getAccounts(params []json.RawMessage) (interface{}, error) {
+	var text string
+	if err := decodeParams(params, 1, &text); err != nil {
+		return nil, err
+	}
+
+	result := []resp.AccountResult{}
+	for _, a := range strings.Split(text, ",") {
+		address, err := decodeAddress(a)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, s.accountResult(address))
+	}
+
+	return result, nil
+}
+
+func (s *Server) accountResult(address crypto.Address) resp.AccountResult {
+	n := s.Nexus
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	stake := n.state.stakeOf(address)
+	unclaimed := n.calculator().Unclaimed(stake.Claims, types.Timestamp{Value: n.time}).String()
+
+	account := resp.AccountResult{
+		Address:   address.String(),
+		Name:      "anonymous",
+		Stakes:    resp.StakeResult{Amount: stake.Amount.String(), Time: uint(stake.Time), Unclaimed: unclaimed},
+		Stake:     stake.Amount.String(),
+		Unclaimed: unclaimed,
+		Relay:     "0",
+		Validator: "Invalid",
+		Storage:   resp.StorageResult{Archives: []resp.ArchiveResult{}},
+		Balances:  []resp.BalanceResult{},
+		Txs:       []string{},
+	}
+
+	for _, t := range n.sortedTokens() {
+		balance := n.state.balance(t.Symbol, address)
+		if balance.Sign() == 0 {
+			continue
+		}
+
+		account.Balances = append(account.Balances, resp.BalanceResult{
+			Chain:    ChainName,
+			Amount:   balance.String(),
+			Symbol:   t.Symbol,
+			Decimals: uint(t.Decimals),
+			Ids:      []string{},
+		})
+	}
+
+	return account
+}
+
+func (s *Server) getBlockHeight(params []json.RawMessage) (interface{}, error) {
+	return strconv.FormatUint(uint64(s.Nexus.Height()), 10), nil
+}
+
+func (s *Server) getBlockByHeight(params []json.RawMessage) (interface{}, error) {
+	var chain, heightText string
+	if err := decodeParams(params, 2, &chain, &heightText); err != nil {
+		return nil, err
+	}
+
+	height, err := strconv.ParseUint(heightText, 10, 32)
+	if err != nil {
+		return nil, &paramsError{"invalid height " + heightText}
+	}
+
+	block, ok := s.Nexus.Block(uint(height))
+	if !ok {
+		return nil, fmt.Errorf("block not found")
+	}
+
+	result := resp.BlockResult{
+		Hash:             block.Hash.String(),
+		PreviousHash:     block.PreviousHash.String(),
+		Timestamp:        uint(block.Timestamp),
+		Height:           block.Height,
+		ChainAddress:     ContractAddress(ChainName).String(),
+		Txs:              []resp.TransactionResult{},
+		ValidatorAddress: crypto.NullAddress().String(),
+		Reward:           "0",
+		Events:           []resp.EventResult{},
+		Oracles:          []resp.OracleResult{},
+	}
+
+	for _, record := range block.Transactions {
+		tx := transactionResult(record)
+		result.Txs = append(result.Txs, tx)
+		result.Events = append(result.Events, tx.Events...)
+	}
+
+	return result, nil
+}
+
+func (s *Server) getTransaction(params []json.RawMessage) (interface{}, error) {
+	var text string
+	if err := decodeParams(params, 1, &text); err != nil {
+		return nil, err
+	}
+
+	hash, err := parseHash(text)
+	if err != nil {
+		return nil, err
+	}
+
+	record, ok := s.Nexus.Transaction(hash)
+	if !ok {
+		return nil, ErrTransactionUnknown
+	}
+
+	return transactionResult(record), nil
+}
+
+func parseHash(text string) (crypto.Hash, error) {
+	hash, err := crypto.ParseHash(text)
+	if err != nil {
+		return crypto.Hash{}, &paramsError{"invalid hash " + text}
+	}
+
+	return hash, nil
+}
+
+func (s *Server) getAddressTransactions(params []json.RawMessage) (interface{}, error) {
+	var text string
+	page, pageSize := 1, 10
+	if err := decodeParams(params, 1, &text, &page, &pageSize); err != nil {
+		return nil, err
+	}
+
+	if page < 1 || pageSize < 1 {
+		return nil, &paramsError{"invalid page or page size"}
+	}
+
+	address, err := decodeAddress(text)
+	if err != nil {
+		return nil, err
+	}
+
+	records := s.Nexus.AddressTransactions(address)
+
+	result := resp.PaginatedResult[resp.AddressTransactionsResult]{
+		Page:       uint(page),
+		PageSize:   uint(pageSize),
+		Total:      uint(len(records)),
+		TotalPages: uint((len(records) + pageSize - 1) / pageSize),
+		Result:     resp.AddressTransactionsResult{Address: address.String(), Txs: []resp.TransactionResult{}},
+	}
+
+	for i := (page - 1) * pageSize; i < len(records) && i < page*pageSize; i++ {
+		result.Result.Txs = append(result.Result.Txs, transactionResult(records[i]))
+	}
+
+	return result, nil
+}
+
+func (s *Server) getAddressTransactionCount(params []json.RawMessage) (interface{}, error) {
+	var text string
+	if err := decodeParams(params, 1, &text); err != nil {
+		return nil, err
+	}
+
+	address, err := decodeAddress(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return len(s.Nexus.AddressTransactions(address)), nil
+}
+
+func (s *Server) getToken(params []json.RawMessage) (interface{}, error) {
+	var symbol string
+	if err := decodeParams(params, 1, &symbol); err != nil {
+		return nil, err
+	}
+
+	info, ok := s.Nexus.Token(symbol)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTokenNotFound, symbol)
+	}
+
+	return tokenResult(info, s.Nexus.Supply(symbol)), nil
+}
+
+func (s *Server) getTokens(params []json.RawMessage) (interface{}, error) {
+	result := []resp.TokenResult{}
+	for _, info := range s.Nexus.Tokens() {
+		result = append(result, tokenResult(info, s.Nexus.Supply(info.Symbol)))
+	}
+
+	return result, nil
+}
+
+func tokenResult(info token.TokenInfo, supply *big.Int) resp.TokenResult {
+	return resp.TokenResult{
+		Symbol:        info.Symbol,
+		Name:          info.Name,
+		Decimals:      int(info.Decimals),
+		CurrentSupply: supply.String(),
+		MaxSupply:     info.MaxSupply.String(),
+		BurnedSupply:  "0",
+		Address:       ContractAddress(info.Symbol).String(),
+		Owner:         info.Owner.String(),
+		Flags:         strings.Join(info.Flags.ToSlice(), ", "),
+		Script:        hex.EncodeToString(info.Script),
+		Series:        []resp.TokenSeriesResult{},
+		External:      []resp.TokenExternalResult{},
+		Price:         []resp.TokenPriceResult{},
+	}
+}
+
+func (s *Server) invokeRawScript(params []json.RawMessage) (interface{}, error) {
+	var chain, scriptText string
+	if err := decodeParams(params, 2, &chain, &scriptText); err != nil {
+		return nil, err
+	}
+
+	script, err := hex.DecodeString(scriptText)
+	if err != nil {
+		return nil, &paramsError{"invalid script: " + err.Error()}
+	}
+
+	execution := s.Nexus.InvokeScript(script)
+	if execution.State != vm.Halt {
+		return nil, fmt.Errorf("script execution failed: %s", execution.Err.Error())
+	}
+
+	// node returns values in the order they were pushed, so result of the first call goes first
+	results := encodeResults(execution.Results)
+	slices.Reverse(results)
+
+	result := resp.ScriptResult{
+		Events:  eventResults(execution),
+		Results: results,
+		Oracles: []resp.OracleResult{},
+	}
+	if len(result.Results) > 0 {
+		result.Result = result.Results[0]
+	}
+
+	return result, nil
+}
+
+func (s *Server) sendRawTransaction(params []json.RawMessage) (interface{}, error) {
+	var txText string
+	if err := decodeParams(params, 1, &txText); err != nil {
+		return nil, err
+	}
+
+	data, err := hex.DecodeString(txText)
+	if err != nil {
+		return nil, &paramsError{"invalid transaction: " + err.Error()}
+	}
+
+	var tx blockchain.Transaction
+	br := io.NewBinReaderFromBuf(data)
+	tx.Deserialize(br)
+	if br.Err != nil {
+		return nil, &paramsError{"invalid transaction: " + br.Err.Error()}
+	}
+
+	record, err := s.Nexus.SendTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return record.Transaction.Hash.String(), nil
+}
+
+func transactionResult(record *TransactionRecord) resp.TransactionResult {
+	tx := record.Transaction
+
+	result := resp.TransactionResult{
+		Hash:         tx.Hash.String(),
+		ChainAddress: ContractAddress(ChainName).String(),
+		Timestamp:    uint(record.Timestamp),
+		BlockHeight:  int(record.BlockHeight),
+		BlockHash:    record.BlockHash.String(),
+		Script:       hex.EncodeToString(tx.Script),
+		Payload:      hex.EncodeToString(tx.Payload),
+		Events:       eventResults(record.ExecutionResult),
+		State:        record.State.String(),
+		Fee:          record.Fee.String(),
+		Signatures:   []resp.SignatureResult{},
+		Expiration:   uint(tx.Expiration),
+	}
+
+	if results := encodeResults(record.Results); len(results) > 0 {
+		result.Result = results[0]
+	}
+
+	for _, signature := range tx.Signatures {
+		result.Signatures = append(result.Signatures, resp.SignatureResult{
+			Kind: signature.Kind().String(),
+			Data: hex.EncodeToString(signature.Bytes()),
+		})
+	}
+
+	return result
+}
+
+func eventResults(execution ExecutionResult) []resp.EventResult {
+	result := []resp.EventResult{}
+	for _, e := range execution.Events {
+		result = append(result, resp.EventResult{
+			Address:  e.Address.String(),
+			Contract: e.Contract,
+			Kind:     e.Kind.String(),
+			Data:     hex.EncodeToString(e.Data),
+		})
+	}
+
+	return result
+}
+
+func encodeResults(objects []vm.VMObject) []string {
+	result := []string{}
+	for i := range objects {
+		result = append(result, hex.EncodeToString(io.Serialize(&objects[i])))
+	}
+
+	return result
+}
@@ -0,0 +1,282 @@
+package simulator_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/phantasma-io/phantasma-go/pkg/blockchain"
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	"github.com/phantasma-io/phantasma-go/pkg/rpc"
+	"github.com/phantasma-io/phantasma-go/pkg/simulator"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	gasPrice = big.NewInt(100000)
+	gasLimit = big.NewInt(10000)
+)
+
+func newKeys(b byte) cryptography.PhantasmaKeys {
+	seed := make([]byte, 32)
+	seed[0] = b
+	return cryptography.NewPhantasmaKeys(seed)
+}
+
+func newFundedNexus(t *testing.T, keys cryptography.PhantasmaKeys) *simulator.Nexus {
+	nexus := simulator.NewNexus()
+	require.Nil(t, nexus.Mint("SOUL", keys.Address(), big.NewInt(1000_00000000)))
+	require.Nil(t, nexus.Mint("KCAL", keys.Address(), big.NewInt(100_0000000000)))
+	return nexus
+}
+
+func signedTx(nexus *simulator.Nexus, keys cryptography.PhantasmaKeys, script []byte) blockchain.Transaction {
+	tx := blockchain.NewTransaction(nexus.Name, simulator.ChainName, script, nexus.Time()+3600, nil)
+	tx.Sign(keys)
+	return tx
+}
+
+func TestTransfer(t *testing.T) {
+	sender := newKeys(1)
+	receiver := newKeys(2)
+	nexus := newFundedNexus(t, sender)
+
+	amount := big.NewInt(10_00000000)
+	script := scriptbuilder.BeginScript().
+		AllowGas(sender.Address(), cryptography.NullAddress(), gasPrice, gasLimit).
+		TransferTokens("SOUL", sender.Address(), receiver.Address(), amount).
+		SpendGas(sender.Address()).
+		EndScript()
+
+	record, err := nexus.SendTransaction(signedTx(nexus, sender, script))
+	require.Nil(t, err)
+	require.Equal(t, vm.Halt, record.State, record.Err)
+
+	assert.Equal(t, amount, nexus.BalanceOf("SOUL", receiver.Address()))
+	assert.Equal(t, big.NewInt(990_00000000), nexus.BalanceOf("SOUL", sender.Address()))
+
+	fee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(record.GasUsed))
+	assert.Equal(t, fee, record.Fee)
+	assert.Equal(t, new(big.Int).Sub(big.NewInt(100_0000000000), fee), nexus.BalanceOf("KCAL", sender.Address()))
+
+	kinds := []event.EventKind{}
+	for _, e := range record.Events {
+		kinds = append(kinds, e.Kind)
+	}
+	assert.Equal(t, []event.EventKind{event.GasEscrow, event.TokenSend, event.TokenReceive, event.GasPayment}, kinds)
+
+	data := io.Deserialize[*event.TokenEventData](record.Events[2].Data)
+	assert.Equal(t, "SOUL", data.Symbol)
+	assert.Equal(t, amount, data.Value)
+	assert.Equal(t, receiver.Address().String(), record.Events[2].Address.String())
+
+	assert.Equal(t, uint(1), nexus.Height())
+	assert.Len(t, nexus.AddressTransactions(receiver.Address()), 1)
+}
+
+func TestTransferFaults(t *testing.T) {
+	sender := newKeys(1)
+	receiver := newKeys(2)
+	nexus := newFundedNexus(t, sender)
+
+	// not signed by the source address
+	script := scriptbuilder.BeginScript().
+		TransferTokens("SOUL", sender.Address(), receiver.Address(), big.NewInt(1)).
+		EndScript()
+	record, err := nexus.SendTransaction(signedTx(nexus, receiver, script))
+	require.Nil(t, err)
+	assert.Equal(t, vm.Fault, record.State)
+	assert.True(t, errors.Is(record.Err, simulator.ErrNotWitness))
+
+	// state is reverted, except for gas used up to the fault
+	script = scriptbuilder.BeginScript().
+		AllowGas(sender.Address(), cryptography.NullAddress(), gasPrice, gasLimit).
+		TransferTokens("SOUL", sender.Address(), receiver.Address(), big.NewInt(5000_00000000)).
+		SpendGas(sender.Address()).
+		EndScript()
+	record, err = nexus.SendTransaction(signedTx(nexus, sender, script))
+	require.Nil(t, err)
+	assert.Equal(t, vm.Fault, record.State)
+	assert.True(t, errors.Is(record.Err, simulator.ErrInsufficientFunds))
+	assert.Equal(t, big.NewInt(0), nexus.BalanceOf("SOUL", receiver.Address()))
+	assert.NotZero(t, record.GasUsed)
+
+	fee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(record.GasUsed))
+	assert.Equal(t, fee, record.Fee)
+	assert.Equal(t, new(big.Int).Sub(big.NewInt(100_0000000000), fee), nexus.BalanceOf("KCAL", sender.Address()))
+	require.Len(t, record.Events, 2)
+	assert.Equal(t, event.GasEscrow, record.Events[0].Kind)
+	assert.Equal(t, event.GasPayment, record.Events[1].Kind)
+
+	tx := blockchain.NewTransaction("mainnet", simulator.ChainName, script, nexus.Time()+60, nil)
+	_, err = nexus.SendTransaction(tx)
+	assert.True(t, errors.Is(err, simulator.ErrInvalidNexus))
+
+	tx = signedTx(nexus, sender, script)
+	nexus.AdvanceTime(7200)
+	_, err = nexus.SendTransaction(tx)
+	assert.True(t, errors.Is(err, simulator.ErrExpired))
+}
+
+func TestStakeUnstake(t *testing.T) {
+	keys := newKeys(1)
+	nexus := newFundedNexus(t, keys)
+
+	stake := scriptbuilder.BeginScript().
+		AllowGas(keys.Address(), cryptography.NullAddress(), gasPrice, gasLimit).
+		Stake(keys.Address(), big.NewInt(100_00000000)).
+		SpendGas(keys.Address()).
+		EndScript()
+
+	record, err := nexus.SendTransaction(signedTx(nexus, keys, stake))
+	require.Nil(t, err)
+	require.Equal(t, vm.Halt, record.State, record.Err)
+
+	assert.Equal(t, big.NewInt(100_00000000), nexus.StakeOf(keys.Address()).Amount)
+	assert.Equal(t, big.NewInt(900_00000000), nexus.BalanceOf("SOUL", keys.Address()))
+	assert.Equal(t, event.TokenStake, record.Events[3].Kind)
+	assert.Equal(t, "stake", record.Events[3].Contract)
+
+	result := nexus.InvokeScript(scriptbuilder.BeginScript().
+		CallContract("stake", "GetStake", keys.Address()).
+		EndScript())
+	require.Equal(t, vm.Halt, result.State)
+	assert.Equal(t, big.NewInt(100_00000000), result.Results[0].AsNumber())
+
+	unstake := scriptbuilder.BeginScript().
+		AllowGas(keys.Address(), cryptography.NullAddress(), gasPrice, gasLimit).
+		Unstake(keys.Address(), big.NewInt(100_00000000)).
+		SpendGas(keys.Address()).
+		EndScript()
+
+	record, err = nexus.SendTransaction(signedTx(nexus, keys, unstake))
+	require.Nil(t, err)
+	assert.Equal(t, vm.Fault, record.State)
+
+	nexus.AdvanceTime(simulator.DefaultStakeLockTime)
+
+	record, err = nexus.SendTransaction(signedTx(nexus, keys, unstake))
+	require.Nil(t, err)
+	require.Equal(t, vm.Halt, record.State, record.Err)
+	assert.Equal(t, big.NewInt(0), nexus.StakeOf(keys.Address()).Amount)
+	assert.Equal(t, big.NewInt(1000_00000000), nexus.BalanceOf("SOUL", keys.Address()))
+}
+
+func TestServerWithRPCClient(t *testing.T) {
+	sender := newKeys(1)
+	receiver := newKeys(2)
+	nexus := newFundedNexus(t, sender)
+
+	server := httptest.NewServer(simulator.NewServer(nexus))
+	defer server.Close()
+
+	client := rpc.NewRPC(server.URL)
+
+	script := scriptbuilder.BeginScript().
+		AllowGas(sender.Address(), cryptography.NullAddress(), gasPrice, gasLimit).
+		TransferTokens("KCAL", sender.Address(), receiver.Address(), big.NewInt(5_0000000000)).
+		SpendGas(sender.Address()).
+		EndScript()
+	tx := signedTx(nexus, sender, script)
+
+	hash, err := client.SendRawTransaction(hex.EncodeToString(tx.Bytes()))
+	require.Nil(t, err)
+	assert.Equal(t, tx.Hash.String(), hash)
+
+	txResult, err := client.GetTransaction(hash)
+	require.Nil(t, err)
+	assert.True(t, txResult.StateIsSuccess())
+	assert.Equal(t, 1, txResult.BlockHeight)
+	assert.Len(t, txResult.Events, 4)
+	assert.Equal(t, "TokenReceive", txResult.Events[2].Kind)
+	require.Len(t, txResult.Signatures, 1)
+	assert.Equal(t, "Ed25519", txResult.Signatures[0].Kind)
+
+	account, err := client.GetAccount(receiver.Address().String())
+	require.Nil(t, err)
+	require.Len(t, account.Balances, 1)
+	assert.Equal(t, "KCAL", account.Balances[0].Symbol)
+	assert.Equal(t, "50000000000", account.Balances[0].Amount)
+
+	height, err := client.GetBlockHeight(simulator.ChainName)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(1), height)
+
+	block, err := client.GetBlockByHeight(simulator.ChainName, "1")
+	require.Nil(t, err)
+	assert.Equal(t, hash, block.Txs[0].Hash)
+
+	token, err := client.GetToken("SOUL", false)
+	require.Nil(t, err)
+	assert.Equal(t, "100000000000", token.CurrentSupply)
+	assert.True(t, token.IsStakable())
+
+	count, err := client.GetAddressTransactionCount(receiver.Address().String(), simulator.ChainName)
+	require.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	invoked, err := scriptbuilder.Assemble(`
+		LOAD r0, "simulated"
+		PUSH r0
+		RET
+	`)
+	require.Nil(t, err)
+	scriptResult, err := client.InvokeRawScript(simulator.ChainName, hex.EncodeToString(invoked))
+	require.Nil(t, err)
+	assert.Equal(t, "simulated", scriptResult.DecodeResult().AsString())
+
+	_, err = client.SendRawTransaction(hex.EncodeToString(tx.Bytes()))
+	assert.NotNil(t, err)
+}
+
+func TestClaim(t *testing.T) {
+	keys := newKeys(1)
+	nexus := newFundedNexus(t, keys)
+
+	stake := scriptbuilder.BeginScript().
+		AllowGas(keys.Address(), cryptography.NullAddress(), gasPrice, gasLimit).
+		Stake(keys.Address(), big.NewInt(500_00000000)).
+		SpendGas(keys.Address()).
+		EndScript()
+
+	record, err := nexus.SendTransaction(signedTx(nexus, keys, stake))
+	require.Nil(t, err)
+	require.Equal(t, vm.Halt, record.State, record.Err)
+
+	claim := scriptbuilder.BeginScript().
+		AllowGas(keys.Address(), cryptography.NullAddress(), gasPrice, gasLimit).
+		Claim(keys.Address(), keys.Address()).
+		SpendGas(keys.Address()).
+		EndScript()
+
+	record, err = nexus.SendTransaction(signedTx(nexus, keys, claim))
+	require.Nil(t, err)
+	assert.Equal(t, vm.Fault, record.State)
+
+	nexus.AdvanceTime(86400)
+	assert.Equal(t, big.NewInt(1_0000000000), nexus.UnclaimedOf(keys.Address()))
+
+	result := nexus.InvokeScript(scriptbuilder.BeginScript().
+		CallContract("stake", "GetUnclaimed", keys.Address()).
+		EndScript())
+	require.Equal(t, vm.Halt, result.State)
+	assert.Equal(t, big.NewInt(1_0000000000), result.Results[0].AsNumber())
+
+	record, err = nexus.SendTransaction(signedTx(nexus, keys, claim))
+	require.Nil(t, err)
+	require.Equal(t, vm.Halt, record.State, record.Err)
+
+	minted := false
+	for _, e := range record.Events {
+		minted = minted || (e.Kind == event.TokenMint && e.Contract == "stake")
+	}
+	assert.True(t, minted)
+	assert.Equal(t, big.NewInt(0), nexus.UnclaimedOf(keys.Address()))
+}
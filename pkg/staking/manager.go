@@ -0,0 +1,146 @@
+// Package staking builds and signs staking transactions after checking their preconditions
+// with read-only calls of the stake contract.
+package staking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/blockchain"
+	crypto "github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/rpc"
+	"github.com/phantasma-io/phantasma-go/pkg/txbuilder"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+)
+
+var (
+	ErrNothingToClaim    = errors.New("nothing to claim")
+	ErrInsufficientStake = errors.New("insufficient stake")
+	ErrStakeLocked       = errors.New("stake is locked")
+)
+
+// Client is a subset of RPC methods used by Manager, it's implemented by rpc.PhantasmaRPC
+type Client interface {
+	MultiCall(ctx context.Context, chain string, calls ...rpc.ContractCall) ([]*vm.VMObject, error)
+	SendRawTransaction(txData string) (string, error)
+}
+
+// Info is a stake of an address as reported by the stake contract
+type Info struct {
+	Staked    *big.Int
+	Unclaimed *big.Int
+	// TimeBeforeUnstake is the time left until stake can be unstaked
+	TimeBeforeUnstake time.Duration
+}
+
+// Manager builds and signs staking transactions of a single address. Builder's Now is used
+// for unstake scheduling as well.
+type Manager struct {
+	Client Client
+	txbuilder.Builder
+}
+
+// NewManager returns manager of staking transactions signed by keys on the main chain of the nexus
+func NewManager(client Client, nexus string, keys crypto.PhantasmaKeys) *Manager {
+	return &Manager{Client: client, Builder: txbuilder.New(nexus, keys)}
+}
+
+// Info returns stake, unclaimed KCAL and time left before unstake of the manager address
+func (m *Manager) Info(ctx context.Context) (Info, error) {
+	address := m.Keys.Address()
+
+	var info Info
+	var left *big.Int
+	_, err := m.Client.MultiCall(ctx, m.Chain,
+		rpc.NewContractCall("stake", "GetStake", address).Into(&info.Staked),
+		rpc.NewContractCall("stake", "GetUnclaimed", address).Into(&info.Unclaimed),
+		rpc.NewContractCall("stake", "GetTimeBeforeUnstake", address).Into(&left))
+	if err != nil {
+		return Info{}, err
+	}
+
+	info.TimeBeforeUnstake = time.Duration(left.Int64()) * time.Second
+	return info, nil
+}
+
+// UnstakeTime returns the time stake can be unstaked at
+func (m *Manager) UnstakeTime(ctx context.Context) (time.Time, error) {
+	info, err := m.Info(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return m.Now().Add(info.TimeBeforeUnstake), nil
+}
+
+// Claim returns signed transaction claiming generated KCAL, ErrNothingToClaim is returned if no KCAL is generated yet
+func (m *Manager) Claim(ctx context.Context) (blockchain.Transaction, error) {
+	info, err := m.Info(ctx)
+	if err != nil {
+		return blockchain.Transaction{}, err
+	}
+
+	if info.Unclaimed.Sign() == 0 {
+		return blockchain.Transaction{}, ErrNothingToClaim
+	}
+
+	address := m.Keys.Address()
+	return m.Transaction(func(sb scriptbuilder.ScriptBuilder) scriptbuilder.ScriptBuilder {
+		return sb.Claim(address, address)
+	}), nil
+}
+
+// Compound returns signed transaction claiming generated KCAL and staking amount of SOUL more
+func (m *Manager) Compound(ctx context.Context, amount *big.Int) (blockchain.Transaction, error) {
+	if amount.Sign() <= 0 {
+		return blockchain.Transaction{}, fmt.Errorf("invalid amount %s", amount.String())
+	}
+
+	info, err := m.Info(ctx)
+	if err != nil {
+		return blockchain.Transaction{}, err
+	}
+
+	if info.Unclaimed.Sign() == 0 {
+		return blockchain.Transaction{}, ErrNothingToClaim
+	}
+
+	return m.Transaction(func(sb scriptbuilder.ScriptBuilder) scriptbuilder.ScriptBuilder {
+		return sb.Compound(m.Keys.Address(), amount)
+	}), nil
+}
+
+// Unstake returns signed transaction unstaking amount of SOUL. ErrInsufficientStake is returned if less is
+// staked and ErrStakeLocked if lock period hasn't passed yet, UnstakeTime tells when it passes.
+func (m *Manager) Unstake(ctx context.Context, amount *big.Int) (blockchain.Transaction, error) {
+	if amount.Sign() <= 0 {
+		return blockchain.Transaction{}, fmt.Errorf("invalid amount %s", amount.String())
+	}
+
+	info, err := m.Info(ctx)
+	if err != nil {
+		return blockchain.Transaction{}, err
+	}
+
+	if info.Staked.Cmp(amount) < 0 {
+		return blockchain.Transaction{}, fmt.Errorf("%w: %s staked, %s requested", ErrInsufficientStake, info.Staked, amount)
+	}
+
+	if info.TimeBeforeUnstake > 0 {
+		at := m.Now().Add(info.TimeBeforeUnstake)
+		return blockchain.Transaction{}, fmt.Errorf("%w until %s", ErrStakeLocked, at.UTC().Format(time.RFC3339))
+	}
+
+	return m.Transaction(func(sb scriptbuilder.ScriptBuilder) scriptbuilder.ScriptBuilder {
+		return sb.Unstake(m.Keys.Address(), amount)
+	}), nil
+}
+
+// Send broadcasts transaction and returns its hash
+func (m *Manager) Send(tx blockchain.Transaction) (string, error) {
+	return txbuilder.Send(m.Client, tx)
+}
@@ -0,0 +1,147 @@
+package staking_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/blockchain"
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/rpc"
+	"github.com/phantasma-io/phantasma-go/pkg/simulator"
+	"github.com/phantasma-io/phantasma-go/pkg/staking"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newManager(t *testing.T) (*staking.Manager, *simulator.Nexus) {
+	seed := make([]byte, 32)
+	seed[0] = 1
+	keys := cryptography.NewPhantasmaKeys(seed)
+
+	nexus := simulator.NewNexus()
+	require.Nil(t, nexus.Mint("SOUL", keys.Address(), big.NewInt(1000_00000000)))
+	require.Nil(t, nexus.Mint("KCAL", keys.Address(), big.NewInt(100_0000000000)))
+
+	server := httptest.NewServer(simulator.NewServer(nexus))
+	t.Cleanup(server.Close)
+
+	m := staking.NewManager(rpc.NewRPC(server.URL), nexus.Name, keys)
+	m.Now = func() time.Time { return time.Unix(int64(nexus.Time()), 0) }
+	return m, nexus
+}
+
+func stakeSoul(t *testing.T, m *staking.Manager, nexus *simulator.Nexus, amount *big.Int) {
+	script := scriptbuilder.BeginScript().
+		AllowGas(m.Keys.Address(), cryptography.NullAddress(), m.GasPrice, m.GasLimit).
+		Stake(m.Keys.Address(), amount).
+		SpendGas(m.Keys.Address()).
+		EndScript()
+
+	tx := blockchain.NewTransaction(nexus.Name, simulator.ChainName, script, nexus.Time()+3600, nil)
+	tx.Sign(m.Keys)
+
+	record, err := nexus.SendTransaction(tx)
+	require.Nil(t, err)
+	require.Equal(t, vm.Halt, record.State, record.Err)
+}
+
+func TestInfo(t *testing.T) {
+	m, nexus := newManager(t)
+	ctx := context.Background()
+
+	info, err := m.Info(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(0), info.Staked)
+	assert.Equal(t, big.NewInt(0), info.Unclaimed)
+	assert.Equal(t, time.Duration(0), info.TimeBeforeUnstake)
+
+	stakeSoul(t, m, nexus, big.NewInt(500_00000000))
+	nexus.AdvanceTime(3600)
+
+	info, err = m.Info(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(500_00000000), info.Staked)
+	assert.Equal(t, big.NewInt(0), info.Unclaimed)
+	assert.Equal(t, time.Duration(simulator.DefaultStakeLockTime-3600)*time.Second, info.TimeBeforeUnstake)
+
+	at, err := m.UnstakeTime(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, m.Now().Add(info.TimeBeforeUnstake), at)
+}
+
+func TestClaim(t *testing.T) {
+	m, nexus := newManager(t)
+	ctx := context.Background()
+
+	_, err := m.Claim(ctx)
+	assert.True(t, errors.Is(err, staking.ErrNothingToClaim))
+
+	stakeSoul(t, m, nexus, big.NewInt(500_00000000))
+	nexus.AdvanceTime(86400)
+
+	info, err := m.Info(ctx)
+	require.Nil(t, err)
+	// 500 SOUL generate 1 KCAL per day
+	assert.Equal(t, big.NewInt(1_0000000000), info.Unclaimed)
+
+	before := nexus.BalanceOf("KCAL", m.Keys.Address())
+
+	tx, err := m.Claim(ctx)
+	require.Nil(t, err)
+	hash, err := m.Send(tx)
+	require.Nil(t, err)
+	assert.Equal(t, tx.Hash.String(), hash)
+
+	after := nexus.BalanceOf("KCAL", m.Keys.Address())
+	assert.Equal(t, 1, after.Cmp(before))
+
+	info, err = m.Info(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(0), info.Unclaimed)
+}
+
+func TestCompound(t *testing.T) {
+	m, nexus := newManager(t)
+	ctx := context.Background()
+
+	stakeSoul(t, m, nexus, big.NewInt(500_00000000))
+	nexus.AdvanceTime(86400)
+
+	tx, err := m.Compound(ctx, big.NewInt(100_00000000))
+	require.Nil(t, err)
+	_, err = m.Send(tx)
+	require.Nil(t, err)
+
+	info, err := m.Info(ctx)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(600_00000000), info.Staked)
+	assert.Equal(t, big.NewInt(0), info.Unclaimed)
+}
+
+func TestUnstake(t *testing.T) {
+	m, nexus := newManager(t)
+	ctx := context.Background()
+
+	stakeSoul(t, m, nexus, big.NewInt(500_00000000))
+
+	_, err := m.Unstake(ctx, big.NewInt(600_00000000))
+	assert.True(t, errors.Is(err, staking.ErrInsufficientStake))
+
+	_, err = m.Unstake(ctx, big.NewInt(100_00000000))
+	assert.True(t, errors.Is(err, staking.ErrStakeLocked))
+
+	nexus.AdvanceTime(simulator.DefaultStakeLockTime)
+
+	tx, err := m.Unstake(ctx, big.NewInt(100_00000000))
+	require.Nil(t, err)
+	_, err = m.Send(tx)
+	require.Nil(t, err)
+
+	assert.Equal(t, big.NewInt(400_00000000), nexus.StakeOf(m.Keys.Address()).Amount)
+}
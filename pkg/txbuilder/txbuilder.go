@@ -0,0 +1,79 @@
+// Package txbuilder builds signed transactions paying gas from the signing address. It's shared by
+// packages orchestrating contract calls of a single address, like staking.
+package txbuilder
+
+import (
+	"encoding/hex"
+	"math/big"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/blockchain"
+	crypto "github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+)
+
+const (
+	// DefaultExpiration is the default time a transaction is valid for
+	DefaultExpiration = 5 * time.Minute
+)
+
+var (
+	// DefaultGasPrice is the default gas price of transactions
+	DefaultGasPrice = big.NewInt(100000)
+	// DefaultGasLimit is the default gas limit of transactions
+	DefaultGasLimit = big.NewInt(21000)
+)
+
+// Sender is a subset of RPC methods used by Send, it's implemented by rpc.PhantasmaRPC
+type Sender interface {
+	SendRawTransaction(txData string) (string, error)
+}
+
+// Builder builds transactions signed by Keys on Chain of Nexus
+type Builder struct {
+	Keys crypto.PhantasmaKeys
+
+	Nexus      string
+	Chain      string
+	GasPrice   *big.Int
+	GasLimit   *big.Int
+	Expiration time.Duration
+	Payload    []byte
+
+	// Now returns current time, it's used for transaction expiration
+	Now func() time.Time
+}
+
+// New returns builder of transactions signed by keys on the main chain of the nexus
+func New(nexus string, keys crypto.PhantasmaKeys) Builder {
+	return Builder{
+		Keys:       keys,
+		Nexus:      nexus,
+		Chain:      "main",
+		GasPrice:   DefaultGasPrice,
+		GasLimit:   DefaultGasLimit,
+		Expiration: DefaultExpiration,
+		Payload:    domain.SDKPayload,
+		Now:        time.Now,
+	}
+}
+
+// Transaction returns signed transaction with script emitted by build between AllowGas and SpendGas
+// of the signing address
+func (b *Builder) Transaction(build func(sb scriptbuilder.ScriptBuilder) scriptbuilder.ScriptBuilder) blockchain.Transaction {
+	address := b.Keys.Address()
+
+	sb := scriptbuilder.BeginScript().AllowGas(address, crypto.NullAddress(), b.GasPrice, b.GasLimit)
+	script := build(sb).SpendGas(address).EndScript()
+
+	expiration := uint32(b.Now().Add(b.Expiration).Unix())
+	tx := blockchain.NewTransaction(b.Nexus, b.Chain, script, expiration, b.Payload)
+	tx.Sign(b.Keys)
+	return tx
+}
+
+// Send broadcasts transaction and returns its hash
+func Send(client Sender, tx blockchain.Transaction) (string, error) {
+	return client.SendRawTransaction(hex.EncodeToString(tx.Bytes()))
+}
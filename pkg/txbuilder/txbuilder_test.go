@@ -0,0 +1,55 @@
+package txbuilder_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/blockchain"
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	"github.com/phantasma-io/phantasma-go/pkg/txbuilder"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSender []string
+
+func (s *fakeSender) SendRawTransaction(txData string) (string, error) {
+	*s = append(*s, txData)
+	return "hash", nil
+}
+
+func TestTransaction(t *testing.T) {
+	keys := cryptography.NewPhantasmaKeys(make([]byte, 32))
+	b := txbuilder.New("simnet", keys)
+	b.Now = func() time.Time { return time.Unix(1000, 0) }
+
+	tx := b.Transaction(func(sb scriptbuilder.ScriptBuilder) scriptbuilder.ScriptBuilder {
+		return sb.Stake(keys.Address(), big.NewInt(5))
+	})
+	assert.Equal(t, "simnet", tx.NexusName)
+	assert.Equal(t, "main", tx.ChainName)
+	assert.Equal(t, uint32(1000+300), tx.Expiration)
+	assert.True(t, tx.IsSignedBy([]cryptography.Address{keys.Address()}))
+
+	address := keys.Address()
+	script := scriptbuilder.BeginScript().
+		AllowGas(address, cryptography.NullAddress(), txbuilder.DefaultGasPrice, txbuilder.DefaultGasLimit).
+		Stake(address, big.NewInt(5)).
+		SpendGas(address).
+		EndScript()
+	assert.Equal(t, script, tx.Script)
+
+	var sender fakeSender
+	hash, err := txbuilder.Send(&sender, tx)
+	require.Nil(t, err)
+	assert.Equal(t, "hash", hash)
+	require.Len(t, sender, 1)
+
+	data, err := hex.DecodeString(sender[0])
+	require.Nil(t, err)
+	assert.Equal(t, tx.Script, io.Deserialize[*blockchain.Transaction](data).Script)
+}
@@ -1,10 +1,25 @@
 package util
 
 import (
+	"fmt"
 	"math/big"
 	"slices"
 )
 
+// ParseBigInt parses decimal number returned by node as a string, empty string is parsed as zero
+func ParseBigInt(s string) (*big.Int, error) {
+	if s == "" {
+		return big.NewInt(0), nil
+	}
+
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a number", s)
+	}
+
+	return n, nil
+}
+
 func BigIntToCsharpByteArray(n *big.Int) []byte {
 	if n.BitLen() == 0 { // Check if big int is zero
 		return []byte{0x00}
@@ -80,3 +80,16 @@ func TestBigInteger(t *testing.T) {
 		BigIntTestConversions(t, a)
 	}
 }
+
+func TestParseBigInt(t *testing.T) {
+	n, err := ParseBigInt("-12345678901234567890")
+	require.Nil(t, err)
+	require.Equal(t, "-12345678901234567890", n.String())
+
+	n, err = ParseBigInt("")
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(0), n)
+
+	_, err = ParseBigInt("1.5")
+	require.ErrorContains(t, err, `"1.5" is not a number`)
+}
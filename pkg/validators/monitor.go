@@ -0,0 +1,218 @@
+package validators
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+)
+
+// DefaultSlotDuration is the default length of a production slot, it matches the default validator rotation time
+const DefaultSlotDuration = 120 * time.Second
+
+// Production is a block with its producer
+type Production struct {
+	Height    uint
+	Time      time.Time
+	Validator string
+	Reward    *big.Int
+	Txs       int
+}
+
+// NewProduction returns production of the block
+func NewProduction(block resp.BlockResult) (Production, error) {
+	reward, err := parseReward(block.Reward)
+	if err != nil {
+		return Production{}, fmt.Errorf("block %d: %w", block.Height, err)
+	}
+
+	return Production{
+		Height:    block.Height,
+		Time:      time.Unix(int64(block.Timestamp), 0),
+		Validator: block.ValidatorAddress,
+		Reward:    reward,
+		Txs:       len(block.Txs),
+	}, nil
+}
+
+// Stats are block production statistics of a validator over a window
+type Stats struct {
+	Validator
+	Blocks  int
+	Rewards *big.Int
+	// LastBlock is the time of the last block produced in the window, it's zero if no blocks were produced
+	LastBlock time.Time
+
+	// ProducedSlots is the number of slots validator produced at least one block in
+	ProducedSlots int
+	// ExpectedSlots is the fair share of active slots of a primary validator, it's zero for other validators
+	ExpectedSlots float64
+	// MissedSlots is the number of expected slots validator didn't produce blocks in
+	MissedSlots float64
+	// Uptime is the ratio of produced and expected slots, capped at 1
+	Uptime float64
+}
+
+// Report is block production statistics of validators over a window
+type Report struct {
+	From, To time.Time
+	// Slots is the number of slots in the window, ActiveSlots is the number of slots with blocks
+	Slots       int
+	ActiveSlots int
+
+	// Validators are statistics of validators ordered by produced blocks, most first
+	Validators []Stats
+	// Unknown are producers of blocks which are not in the validator list
+	Unknown []string
+	Events  []Event
+}
+
+// Stats returns statistics of the validator, false is returned if it's not in the report
+func (r Report) Stats(address string) (Stats, bool) {
+	for _, s := range r.Validators {
+		if s.Address == address {
+			return s, true
+		}
+	}
+
+	return Stats{}, false
+}
+
+// Analyze computes statistics of validators from productions. The window from the first to the last
+// block is split into slots, block producers are expected to rotate between primary validators, so every
+// primary validator is expected to produce blocks in an equal share of slots having blocks.
+func Analyze(productions []Production, validators []Validator, slot time.Duration) Report {
+	if slot <= 0 {
+		slot = DefaultSlotDuration
+	}
+
+	r := Report{Validators: make([]Stats, len(validators)), Unknown: []string{}, Events: []Event{}}
+	index := map[string]int{}
+	primaries := 0
+	for i, v := range validators {
+		r.Validators[i] = Stats{Validator: v, Rewards: big.NewInt(0)}
+		index[v.Address] = i
+		if v.Type == Primary {
+			primaries++
+		}
+	}
+
+	if len(productions) > 0 {
+		r.From, r.To = productions[0].Time, productions[0].Time
+		for _, p := range productions {
+			if p.Time.Before(r.From) {
+				r.From = p.Time
+			}
+			if p.Time.After(r.To) {
+				r.To = p.Time
+			}
+		}
+		r.Slots = int(r.To.Sub(r.From)/slot) + 1
+	}
+
+	active := map[int]bool{}
+	produced := map[string]map[int]bool{}
+	unknown := map[string]bool{}
+	for _, p := range productions {
+		s := int(p.Time.Sub(r.From) / slot)
+		active[s] = true
+
+		i, ok := index[p.Validator]
+		if !ok {
+			if !unknown[p.Validator] {
+				unknown[p.Validator] = true
+				r.Unknown = append(r.Unknown, p.Validator)
+			}
+			continue
+		}
+
+		stats := &r.Validators[i]
+		stats.Blocks++
+		if p.Reward != nil {
+			stats.Rewards.Add(stats.Rewards, p.Reward)
+		}
+		if p.Time.After(stats.LastBlock) {
+			stats.LastBlock = p.Time
+		}
+
+		if produced[p.Validator] == nil {
+			produced[p.Validator] = map[int]bool{}
+		}
+		produced[p.Validator][s] = true
+	}
+	r.ActiveSlots = len(active)
+
+	for i := range r.Validators {
+		stats := &r.Validators[i]
+		stats.ProducedSlots = len(produced[stats.Address])
+		if stats.Type != Primary || primaries == 0 || r.ActiveSlots == 0 {
+			continue
+		}
+
+		stats.ExpectedSlots = float64(r.ActiveSlots) / float64(primaries)
+		stats.MissedSlots = max(stats.ExpectedSlots-float64(stats.ProducedSlots), 0)
+		stats.Uptime = min(float64(stats.ProducedSlots)/stats.ExpectedSlots, 1)
+	}
+
+	sort.SliceStable(r.Validators, func(i, j int) bool { return r.Validators[i].Blocks > r.Validators[j].Blocks })
+	return r
+}
+
+// Monitor collects block production of validators
+type Monitor struct {
+	client Client
+
+	Chain string
+	// SlotDuration is the length of a production slot, validator rotation time of governance can be used
+	SlotDuration time.Duration
+}
+
+// NewMonitor returns monitor of the main chain
+func NewMonitor(client Client) *Monitor {
+	return &Monitor{client: client, Chain: "main", SlotDuration: DefaultSlotDuration}
+}
+
+// Productions returns producers of blocks from first to last height, both inclusive, and validator events of these blocks
+func (m *Monitor) Productions(first, last uint64) ([]Production, []Event, error) {
+	productions := []Production{}
+	events := []Event{}
+	err := event.ForEachBlock(m.client, m.Chain, first, last, func(block resp.BlockResult) error {
+		p, err := NewProduction(block)
+		if err != nil {
+			return err
+		}
+		productions = append(productions, p)
+
+		e, err := EventsFromBlock(block)
+		if err != nil {
+			return err
+		}
+		events = append(events, e...)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return productions, events, nil
+}
+
+// Report returns statistics of current validators over blocks from first to last height, both inclusive
+func (m *Monitor) Report(first, last uint64) (Report, error) {
+	validators, err := List(m.client)
+	if err != nil {
+		return Report{}, err
+	}
+
+	productions, events, err := m.Productions(first, last)
+	if err != nil {
+		return Report{}, err
+	}
+
+	r := Analyze(productions, validators, m.SlotDuration)
+	r.Events = events
+	return r, nil
+}
@@ -0,0 +1,112 @@
+// Package validators lists validators of the nexus, decodes validator events and
+// computes block production statistics of validators over a range of blocks.
+package validators
+
+import (
+	"fmt"
+	"math/big"
+
+	crypto "github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+)
+
+type Type uint
+
+const (
+	Invalid   Type = 0
+	Primary   Type = 1
+	Secondary Type = 2
+)
+
+var typeLookup = map[Type]string{
+	Invalid:   `Invalid`,
+	Primary:   `Primary`,
+	Secondary: `Secondary`,
+}
+
+func (t Type) String() string {
+	return typeLookup[t]
+}
+
+// ParseType returns validator type by its name
+func ParseType(s string) (Type, error) {
+	for t, name := range typeLookup {
+		if name == s {
+			return t, nil
+		}
+	}
+
+	return Invalid, fmt.Errorf("unknown validator type %q", s)
+}
+
+// Validator is a validator of the nexus, primary validators produce blocks
+type Validator struct {
+	Address string
+	Type    Type
+}
+
+// Client is a subset of RPC methods used by validators, it's implemented by rpc.PhantasmaRPC
+type Client interface {
+	GetValidators() ([]resp.ValidatorResult, error)
+	GetBlockByHeight(chain string, height string) (resp.BlockResult, error)
+}
+
+// List returns validators in the order reported by node
+func List(client Client) ([]Validator, error) {
+	results, err := client.GetValidators()
+	if err != nil {
+		return nil, err
+	}
+
+	validators := make([]Validator, 0, len(results))
+	for _, r := range results {
+		t, err := ParseType(r.Type)
+		if err != nil {
+			return nil, fmt.Errorf("validator %s: %w", r.Address, err)
+		}
+		validators = append(validators, Validator{Address: r.Address, Type: t})
+	}
+
+	return validators, nil
+}
+
+// Event is a ValidatorPropose, ValidatorElect, ValidatorRemove or ValidatorSwitch event,
+// data of these events is the address of the validator
+type Event struct {
+	Kind      event.EventKind
+	Validator string
+	// Address is the address event was emitted by
+	Address string
+
+	event.Location
+}
+
+// EventFromEvent decodes validator event, false is returned for other events
+func EventFromEvent(e resp.EventResult) (Event, bool, error) {
+	return event.Decode(e, func(kind event.EventKind, br *io.BinReader) Event {
+		var validator crypto.Address
+		validator.Deserialize(br)
+		return Event{Kind: kind, Validator: validator.String(), Address: e.Address}
+	}, event.ValidatorPropose, event.ValidatorElect, event.ValidatorRemove, event.ValidatorSwitch)
+}
+
+// EventsFromBlock returns validator events of successful block transactions and of the block itself, in order
+func EventsFromBlock(block resp.BlockResult) ([]Event, error) {
+	return event.FromBlock(block, EventFromEvent)
+}
+
+// parseReward parses block reward, empty reward is zero
+func parseReward(s string) (*big.Int, error) {
+	if s == "" {
+		return big.NewInt(0), nil
+	}
+
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid reward %q", s)
+	}
+
+	return n, nil
+}
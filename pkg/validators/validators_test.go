@@ -0,0 +1,122 @@
+package validators_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/event/eventtest"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/phantasma-io/phantasma-go/pkg/validators"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	validators []resp.ValidatorResult
+	eventtest.Blocks
+}
+
+func (c *fakeClient) GetValidators() ([]resp.ValidatorResult, error) {
+	return c.validators, nil
+}
+
+func validatorEvent(kind event.EventKind, validator string) resp.EventResult {
+	a, _ := cryptography.FromString(validator)
+	return eventtest.Event(kind, "validator", &a)
+}
+
+func newClient() *fakeClient {
+	a, b, c, x := eventtest.Address(1).String(), eventtest.Address(2).String(), eventtest.Address(3).String(), eventtest.Address(4).String()
+	block := func(height, timestamp uint, producer string) resp.BlockResult {
+		return resp.BlockResult{Height: height, Timestamp: 1700000000 + timestamp, ValidatorAddress: producer, Reward: "100"}
+	}
+
+	blocks := eventtest.Blocks{
+		block(1, 0, a),
+		block(2, 10, a),
+		block(3, 60, b),
+		block(4, 120, a),
+		block(5, 180, a),
+		block(6, 240, x),
+	}
+	blocks[2].Txs = []resp.TransactionResult{{Hash: "A", State: "Halt", Events: []resp.EventResult{
+		validatorEvent(event.ValidatorPropose, c),
+		{Kind: event.TokenSend.String(), Data: "00"},
+	}}}
+	blocks[4].Events = []resp.EventResult{validatorEvent(event.ValidatorSwitch, b)}
+
+	return &fakeClient{
+		validators: []resp.ValidatorResult{
+			{Address: b, Type: "Primary"},
+			{Address: a, Type: "Primary"},
+			{Address: c, Type: "Secondary"},
+		},
+		Blocks: blocks,
+	}
+}
+
+func TestList(t *testing.T) {
+	client := newClient()
+
+	list, err := validators.List(client)
+	require.Nil(t, err)
+	require.Len(t, list, 3)
+	assert.Equal(t, validators.Validator{Address: eventtest.Address(3).String(), Type: validators.Secondary}, list[2])
+
+	client.validators = append(client.validators, resp.ValidatorResult{Address: eventtest.Address(5).String(), Type: "Tertiary"})
+	_, err = validators.List(client)
+	assert.NotNil(t, err)
+}
+
+func TestReport(t *testing.T) {
+	m := validators.NewMonitor(newClient())
+	m.SlotDuration = time.Minute
+
+	r, err := m.Report(1, 6)
+	require.Nil(t, err)
+	assert.Equal(t, time.Unix(1700000000, 0), r.From)
+	assert.Equal(t, time.Unix(1700000240, 0), r.To)
+	assert.Equal(t, 5, r.Slots)
+	assert.Equal(t, 5, r.ActiveSlots)
+	assert.Equal(t, []string{eventtest.Address(4).String()}, r.Unknown)
+
+	require.Len(t, r.Validators, 3)
+	a := r.Validators[0]
+	assert.Equal(t, eventtest.Address(1).String(), a.Address)
+	assert.Equal(t, 4, a.Blocks)
+	assert.Equal(t, big.NewInt(400), a.Rewards)
+	assert.Equal(t, 3, a.ProducedSlots)
+	assert.Equal(t, 2.5, a.ExpectedSlots)
+	assert.Equal(t, 0.0, a.MissedSlots)
+	assert.Equal(t, 1.0, a.Uptime)
+	assert.Equal(t, time.Unix(1700000180, 0), a.LastBlock)
+
+	b, ok := r.Stats(eventtest.Address(2).String())
+	require.True(t, ok)
+	assert.Equal(t, 1, b.ProducedSlots)
+	assert.Equal(t, 1.5, b.MissedSlots)
+	assert.InDelta(t, 0.4, b.Uptime, 1e-9)
+
+	c, ok := r.Stats(eventtest.Address(3).String())
+	require.True(t, ok)
+	assert.Equal(t, 0, c.Blocks)
+	assert.Equal(t, 0.0, c.ExpectedSlots)
+
+	require.Len(t, r.Events, 2)
+	assert.Equal(t, validators.Event{Kind: event.ValidatorPropose, Validator: eventtest.Address(3).String(), Address: "validator", Location: event.Location{Height: 3, TxHash: "A"}}, r.Events[0])
+	assert.Equal(t, event.ValidatorSwitch, r.Events[1].Kind)
+	assert.Equal(t, eventtest.Address(2).String(), r.Events[1].Validator)
+	assert.Equal(t, "", r.Events[1].TxHash)
+
+	_, err = m.Report(6, 7)
+	assert.NotNil(t, err)
+}
+
+func TestAnalyzeEmpty(t *testing.T) {
+	r := validators.Analyze(nil, []validators.Validator{{Address: eventtest.Address(1).String(), Type: validators.Primary}}, 0)
+	assert.Equal(t, 0, r.Slots)
+	assert.Equal(t, 0.0, r.Validators[0].Uptime)
+}
@@ -0,0 +1,59 @@
+package vm
+
+import "fmt"
+
+// ExecutionContext is a code which can be executed by the virtual machine.
+// Contexts are looked up by name with CTX instruction and entered with SWITCH.
+type ExecutionContext interface {
+	Name() string
+	Execute(machine *VirtualMachine) error
+}
+
+// ScriptContext executes a script, starting from its first instruction
+type ScriptContext struct {
+	ContextName string
+	Script      []byte
+}
+
+// NewScriptContext returns a context which executes given script
+func NewScriptContext(name string, script []byte) *ScriptContext {
+	return &ScriptContext{ContextName: name, Script: script}
+}
+
+func (c *ScriptContext) Name() string {
+	return c.ContextName
+}
+
+func (c *ScriptContext) Execute(machine *VirtualMachine) error {
+	return machine.executeScript(c.ContextName, c.Script)
+}
+
+// NativeContext implements a contract in Go. Method name is popped from the stack,
+// same way as node's native contracts do, and the corresponding handler is executed.
+type NativeContext struct {
+	ContractName string
+	Methods      map[string]InteropHandler
+}
+
+// NewNativeContext returns a contract context with given methods
+func NewNativeContext(name string, methods map[string]InteropHandler) *NativeContext {
+	return &NativeContext{ContractName: name, Methods: methods}
+}
+
+func (c *NativeContext) Name() string {
+	return c.ContractName
+}
+
+func (c *NativeContext) Execute(machine *VirtualMachine) error {
+	method, err := machine.PopString()
+	if err != nil {
+		return err
+	}
+
+	handler, ok := c.Methods[method]
+	if !ok {
+		return fmt.Errorf("contract %s has no method %s", c.ContractName, method)
+	}
+
+	return handler(machine)
+}
@@ -0,0 +1,198 @@
+package vm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	"github.com/phantasma-io/phantasma-go/pkg/util"
+)
+
+// Register is an instruction operand which refers to a VM register
+type Register byte
+
+// Value is a LOAD instruction operand: raw bytes loaded into register as a given type
+type Value struct {
+	Type  VMType
+	Bytes []byte
+}
+
+// Instruction is a single decoded VM instruction
+type Instruction struct {
+	Offset int
+	Opcode Opcode
+	// Args holds decoded operands, in order of appearance. Operand types are:
+	// Register, byte (CALL register count), VMType, uint16 (jump offset), uint64 (var int) and Value
+	Args []interface{}
+}
+
+// Disassemble decodes script into a list of instructions
+func Disassemble(script []byte) ([]Instruction, error) {
+	reader := io.NewBinReaderFromBuf(script)
+	var result []Instruction
+
+	for reader.Count < len(script) {
+		offset := reader.Count
+		opcode := Opcode(reader.ReadB())
+
+		operands, ok := opcode.Operands()
+		if !ok {
+			return nil, fmt.Errorf("offset %d: unsupported opcode %s", offset, opcode)
+		}
+
+		instruction := Instruction{Offset: offset, Opcode: opcode, Args: make([]interface{}, 0, len(operands))}
+		for _, kind := range operands {
+			switch kind {
+			case RegisterOperand:
+				instruction.Args = append(instruction.Args, Register(reader.ReadB()))
+			case CountOperand:
+				instruction.Args = append(instruction.Args, reader.ReadB())
+			case TypeOperand:
+				instruction.Args = append(instruction.Args, VMType(reader.ReadB()))
+			case OffsetOperand:
+				instruction.Args = append(instruction.Args, reader.ReadU16LE())
+			case VarIntOperand:
+				instruction.Args = append(instruction.Args, reader.ReadVarUint())
+			case ValueOperand:
+				vmType := VMType(reader.ReadB())
+				b := reader.ReadVarBytes(0xFFFF)
+				if b == nil {
+					b = []byte{}
+				}
+				instruction.Args = append(instruction.Args, Value{Type: vmType, Bytes: b})
+			}
+		}
+
+		if reader.Err != nil {
+			return nil, fmt.Errorf("offset %d: truncated %s instruction: %w", offset, opcode, reader.Err)
+		}
+
+		result = append(result, instruction)
+	}
+
+	return result, nil
+}
+
+// DisassembleToString decodes script into assembly text which can be assembled back into the same script.
+// Jump targets which point to an instruction are replaced with labels.
+func DisassembleToString(script []byte) (string, error) {
+	instructions, err := Disassemble(script)
+	if err != nil {
+		return "", err
+	}
+
+	boundaries := make(map[int]bool, len(instructions))
+	for _, i := range instructions {
+		boundaries[i.Offset] = true
+	}
+	// Jump to the end of the script is valid too
+	boundaries[len(script)] = true
+
+	labels := make(map[int]string)
+	for _, i := range instructions {
+		for _, arg := range i.Args {
+			if target, ok := arg.(uint16); ok && boundaries[int(target)] {
+				labels[int(target)] = labelName(int(target))
+			}
+		}
+	}
+
+	var sb strings.Builder
+	for _, i := range instructions {
+		if label, ok := labels[i.Offset]; ok {
+			sb.WriteString(label + ":\n")
+		}
+
+		sb.WriteString("\t" + i.format(labels) + "\n")
+	}
+
+	if label, ok := labels[len(script)]; ok {
+		sb.WriteString(label + ":\n")
+	}
+
+	return sb.String(), nil
+}
+
+func labelName(offset int) string {
+	return fmt.Sprintf("L%04d", offset)
+}
+
+func (i Instruction) String() string {
+	return i.format(nil)
+}
+
+func (i Instruction) format(labels map[int]string) string {
+	if len(i.Args) == 0 {
+		return i.Opcode.String()
+	}
+
+	args := make([]string, len(i.Args))
+	for n, arg := range i.Args {
+		switch a := arg.(type) {
+		case Register:
+			args[n] = "r" + strconv.Itoa(int(a))
+		case uint16:
+			if label, ok := labels[int(a)]; ok {
+				args[n] = label
+			} else {
+				args[n] = "@" + strconv.Itoa(int(a))
+			}
+		case Value:
+			args[n] = a.String()
+		default:
+			args[n] = fmt.Sprint(a)
+		}
+	}
+
+	return i.Opcode.String() + " " + strings.Join(args, ", ")
+}
+
+// String formats value as an assembly literal. Literal is guaranteed to be encoded back into the same bytes,
+// values which can't be represented by a typed literal are formatted as raw(Type, 0x...).
+func (v Value) String() string {
+	raw := fmt.Sprintf("raw(%s, 0x%s)", v.Type, hex.EncodeToString(v.Bytes))
+
+	switch v.Type {
+	case String:
+		if utf8.Valid(v.Bytes) {
+			return strconv.Quote(string(v.Bytes))
+		}
+	case Bytes:
+		return "0x" + hex.EncodeToString(v.Bytes)
+	case Bool:
+		if bytes.Equal(v.Bytes, []byte{0}) {
+			return "false"
+		} else if bytes.Equal(v.Bytes, []byte{1}) {
+			return "true"
+		}
+	case Number:
+		if len(v.Bytes) > 0 {
+			n := util.BigIntFromCsharpOrPhantasmaByteArray(v.Bytes)
+			if bytes.Equal(EncodeNumber(n), v.Bytes) {
+				return n.String()
+			}
+		}
+	case Enum:
+		if len(v.Bytes) == 4 {
+			return fmt.Sprintf("enum(%d)", binary.LittleEndian.Uint32(v.Bytes))
+		}
+	case Timestamp:
+		if len(v.Bytes) == 4 {
+			return fmt.Sprintf("time(%d)", binary.LittleEndian.Uint32(v.Bytes))
+		}
+	}
+
+	return raw
+}
+
+// EncodeNumber returns canonical LOAD encoding of a number, which is a signed little-endian byte array,
+// same as C# BigInteger.ToByteArray() produces
+func EncodeNumber(n *big.Int) []byte {
+	return util.BigIntToCsharpByteArray(n)
+}
@@ -0,0 +1,786 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"unicode/utf8"
+
+	"github.com/phantasma-io/phantasma-go/pkg/domain/types"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+)
+
+// maxLoadSize is the max length of LOAD instruction payload
+const maxLoadSize = 0xFFFF
+
+// frame holds registers of a script function, created on script start and on every CALL
+type frame struct {
+	registers    []VMObject
+	returnOffset int
+}
+
+func newFrame(registerCount int, returnOffset int) *frame {
+	return &frame{registers: make([]VMObject, registerCount), returnOffset: returnOffset}
+}
+
+// scriptReader reads instruction operands, failing on attempts to read beyond the end of the script
+type scriptReader struct {
+	script []byte
+	ip     int
+	err    error
+}
+
+var errEndOfScript = errors.New("unexpected end of script")
+
+func (r *scriptReader) read8() byte {
+	if r.err != nil || r.ip >= len(r.script) {
+		r.err = errEndOfScript
+		return 0
+	}
+
+	b := r.script[r.ip]
+	r.ip++
+	return b
+}
+
+func (r *scriptReader) read16() uint16 {
+	lo := r.read8()
+	hi := r.read8()
+	return uint16(lo) | uint16(hi)<<8
+}
+
+func (r *scriptReader) readVar(max uint64) uint64 {
+	var n uint64
+	switch b := r.read8(); b {
+	case 0xfd:
+		n = uint64(r.read16())
+	case 0xfe:
+		n = uint64(r.read16()) | uint64(r.read16())<<16
+	case 0xff:
+		n = uint64(r.read16()) | uint64(r.read16())<<16 | uint64(r.read16())<<32 | uint64(r.read16())<<48
+	default:
+		n = uint64(b)
+	}
+
+	if r.err == nil && n > max {
+		r.err = fmt.Errorf("value %d exceeds limit %d", n, max)
+	}
+
+	return n
+}
+
+func (r *scriptReader) readBytes(n int) []byte {
+	if r.err != nil || r.ip+n > len(r.script) {
+		r.err = errEndOfScript
+		return nil
+	}
+
+	b := make([]byte, n)
+	copy(b, r.script[r.ip:r.ip+n])
+	r.ip += n
+	return b
+}
+
+// executeScript runs script from its first instruction until top-level RET or end of the script
+func (m *VirtualMachine) executeScript(contextName string, script []byte) error {
+	frames := []*frame{newFrame(MaxRegisterCount, 0)}
+	reader := &scriptReader{script: script}
+
+	for reader.ip < len(script) {
+		offset := reader.ip
+		opcode := Opcode(reader.read8())
+
+		m.Steps++
+		if m.StepLimit > 0 && m.Steps > m.StepLimit {
+			return &ExecutionError{contextName, offset, opcode, ErrStepLimitExceeded}
+		}
+
+		if err := m.ConsumeGas(InstructionCost(opcode)); err != nil {
+			return &ExecutionError{contextName, offset, opcode, err}
+		}
+
+		if opcode == RET {
+			if len(frames) == 1 {
+				return nil
+			}
+
+			reader.ip = frames[len(frames)-1].returnOffset
+			frames = frames[:len(frames)-1]
+			continue
+		}
+
+		if opcode == CALL {
+			count := int(reader.read8())
+			target := int(reader.read16())
+			if reader.err != nil {
+				return &ExecutionError{contextName, offset, opcode, reader.err}
+			}
+			if count < 1 || count > MaxRegisterCount {
+				return &ExecutionError{contextName, offset, opcode, fmt.Errorf("invalid register count %d", count)}
+			}
+			if target >= len(script) {
+				return &ExecutionError{contextName, offset, opcode, fmt.Errorf("invalid call offset %d", target)}
+			}
+
+			frames = append(frames, newFrame(count, reader.ip))
+			reader.ip = target
+			continue
+		}
+
+		err := m.step(opcode, frames[len(frames)-1], reader)
+		if err == nil {
+			err = reader.err
+		}
+
+		if err != nil {
+			var executionError *ExecutionError
+			if errors.As(err, &executionError) {
+				// Error happened in a context we switched to, it's already annotated
+				return err
+			}
+			return &ExecutionError{contextName, offset, opcode, err}
+		}
+	}
+
+	return nil
+}
+
+// step executes a single instruction, except for CALL and RET which change frames and are handled by executeScript
+func (m *VirtualMachine) step(opcode Opcode, f *frame, r *scriptReader) error {
+	reg := func() *VMObject {
+		index := int(r.read8())
+		if r.err != nil {
+			return &VMObject{}
+		}
+		if index >= len(f.registers) {
+			r.err = fmt.Errorf("invalid register r%d", index)
+			return &VMObject{}
+		}
+		return &f.registers[index]
+	}
+
+	switch opcode {
+	case NOP, DEBUG:
+		return nil
+
+	case MOVE:
+		src, dst := reg(), reg()
+		if r.err == nil && src != dst {
+			*dst = *src
+			*src = VMObject{}
+		}
+
+	case COPY:
+		src, dst := reg(), reg()
+		if r.err == nil {
+			dst.Copy(src)
+		}
+
+	case SWAP:
+		a, b := reg(), reg()
+		if r.err == nil {
+			*a, *b = *b, *a
+		}
+
+	case PUSH:
+		src := reg()
+		if r.err == nil {
+			m.Push(src)
+		}
+
+	case POP:
+		dst := reg()
+		if r.err != nil {
+			return nil
+		}
+		obj, err := m.Pop()
+		if err != nil {
+			return err
+		}
+		*dst = *obj
+
+	case CLEAR:
+		dst := reg()
+		if r.err == nil {
+			*dst = VMObject{}
+		}
+
+	case EXTCALL:
+		src := reg()
+		if r.err != nil {
+			return nil
+		}
+		method, err := src.toString()
+		if err != nil {
+			return err
+		}
+		handler, ok := m.interops[method]
+		if !ok {
+			return fmt.Errorf("unknown interop method %s", method)
+		}
+		if err := handler(m); err != nil {
+			return fmt.Errorf("%s: %w", method, err)
+		}
+
+	case JMP:
+		target := int(r.read16())
+		if r.err != nil {
+			return nil
+		}
+		return r.jump(target)
+
+	case JMPIF, JMPNOT:
+		src := reg()
+		target := int(r.read16())
+		if r.err != nil {
+			return nil
+		}
+		if src.Type != Bool {
+			return fmt.Errorf("expected Bool for conditional jump, got %s", src.Type)
+		}
+		condition := src.Data.(bool)
+		if opcode == JMPNOT {
+			condition = !condition
+		}
+		if condition {
+			return r.jump(target)
+		}
+
+	case THROW:
+		src := reg()
+		if r.err != nil {
+			return nil
+		}
+		message, err := src.toString()
+		if err != nil {
+			message = src.String()
+		}
+		return &ThrowError{Message: message}
+
+	case LOAD:
+		dst := reg()
+		vmType := VMType(r.read8())
+		length := int(r.readVar(maxLoadSize))
+		bytes := r.readBytes(length)
+		if r.err != nil {
+			return nil
+		}
+		var obj VMObject
+		if err := obj.SetValueE(bytes, vmType); err != nil {
+			return err
+		}
+		*dst = obj
+
+	case CAST:
+		src, dst := reg(), reg()
+		vmType := VMType(r.read8())
+		if r.err != nil {
+			return nil
+		}
+		obj, err := castTo(src, vmType)
+		if err != nil {
+			return err
+		}
+		*dst = *obj
+
+	case CAT:
+		a, b, dst := reg(), reg(), reg()
+		if r.err != nil {
+			return nil
+		}
+		return concat(a, b, dst)
+
+	case RANGE, SUBSTR:
+		src, dst := reg(), reg()
+		index := int(r.readVar(maxLoadSize))
+		length := int(r.readVar(maxLoadSize))
+		if r.err != nil {
+			return nil
+		}
+		return slice(src, dst, index, length, src.Type)
+
+	case LEFT, RIGHT:
+		src, dst := reg(), reg()
+		length := int(r.readVar(maxLoadSize))
+		if r.err != nil {
+			return nil
+		}
+		b, err := src.toBytes()
+		if err != nil {
+			return err
+		}
+		index := 0
+		if opcode == RIGHT {
+			index = len(b) - length
+		}
+		resultType := Bytes
+		if src.Type == String {
+			resultType = String
+		}
+		return slice(src, dst, index, length, resultType)
+
+	case SIZE:
+		src, dst := reg(), reg()
+		if r.err != nil {
+			return nil
+		}
+		var size int
+		if src.Type == String {
+			size = utf8.RuneCountInString(src.Data.(string))
+		} else {
+			b, err := src.toBytes()
+			if err != nil {
+				return err
+			}
+			size = len(b)
+		}
+		*dst = *NewNumber(big.NewInt(int64(size)))
+
+	case COUNT:
+		src, dst := reg(), reg()
+		if r.err != nil {
+			return nil
+		}
+		count := 1
+		switch src.Type {
+		case None:
+			count = 0
+		case Struct:
+			count = src.Data.(*VMStruct).Len()
+		}
+		*dst = *NewNumber(big.NewInt(int64(count)))
+
+	case NOT:
+		src, dst := reg(), reg()
+		if r.err != nil {
+			return nil
+		}
+		b, err := src.toBool()
+		if err != nil {
+			return err
+		}
+		*dst = *NewBool(!b)
+
+	case AND, OR, XOR:
+		a, b, dst := reg(), reg(), reg()
+		if r.err != nil {
+			return nil
+		}
+		return logical(opcode, a, b, dst)
+
+	case EQUAL:
+		a, b, dst := reg(), reg(), reg()
+		if r.err == nil {
+			*dst = *NewBool(a.Equals(b))
+		}
+
+	case LT, GT, LTE, GTE:
+		a, b, dst := reg(), reg(), reg()
+		if r.err != nil {
+			return nil
+		}
+		x, y, err := numbers(a, b)
+		if err != nil {
+			return err
+		}
+		c := x.Cmp(y)
+		var result bool
+		switch opcode {
+		case LT:
+			result = c < 0
+		case GT:
+			result = c > 0
+		case LTE:
+			result = c <= 0
+		case GTE:
+			result = c >= 0
+		}
+		*dst = *NewBool(result)
+
+	case INC, DEC:
+		dst := reg()
+		if r.err != nil {
+			return nil
+		}
+		n, err := dst.toNumber()
+		if err != nil {
+			return err
+		}
+		if opcode == INC {
+			n.Add(n, big.NewInt(1))
+		} else {
+			n.Sub(n, big.NewInt(1))
+		}
+		*dst = *NewNumber(n)
+
+	case SIGN, NEGATE, ABS:
+		src, dst := reg(), reg()
+		if r.err != nil {
+			return nil
+		}
+		n, err := src.toNumber()
+		if err != nil {
+			return err
+		}
+		switch opcode {
+		case SIGN:
+			n.SetInt64(int64(n.Sign()))
+		case NEGATE:
+			n.Neg(n)
+		case ABS:
+			n.Abs(n)
+		}
+		*dst = *NewNumber(n)
+
+	case ADD, SUB, MUL, DIV, MOD, SHL, SHR, MIN, MAX, POW:
+		a, b, dst := reg(), reg(), reg()
+		if r.err != nil {
+			return nil
+		}
+		return arithmetic(opcode, a, b, dst)
+
+	case CTX:
+		src, dst := reg(), reg()
+		if r.err != nil {
+			return nil
+		}
+		name, err := src.toString()
+		if err != nil {
+			return err
+		}
+		context, ok := m.FindContext(name)
+		if !ok {
+			return fmt.Errorf("unknown context %s", name)
+		}
+		*dst = VMObject{Type: Object, Data: context}
+
+	case SWITCH:
+		src := reg()
+		if r.err != nil {
+			return nil
+		}
+		context, ok := src.Data.(ExecutionContext)
+		if src.Type != Object || !ok {
+			return fmt.Errorf("expected context object, got %s", src.Type)
+		}
+		return m.SwitchContext(context)
+
+	case PUT:
+		src, dst, key := reg(), reg(), reg()
+		if r.err != nil {
+			return nil
+		}
+		if err := validateKey(key); err != nil {
+			return err
+		}
+		if dst.Type != Struct {
+			*dst = VMObject{Type: Struct, Data: NewVMStruct()}
+		}
+		var k, v VMObject
+		k.Copy(key)
+		v.Copy(src)
+		dst.Data.(*VMStruct).Set(k, v)
+
+	case GET:
+		src, dst, key := reg(), reg(), reg()
+		if r.err != nil {
+			return nil
+		}
+		if src.Type != Struct {
+			return fmt.Errorf("expected Struct, got %s", src.Type)
+		}
+		value, _ := src.Data.(*VMStruct).Get(key)
+		dst.Copy(&value)
+
+	case REMOVE:
+		dst, key := reg(), reg()
+		if r.err != nil {
+			return nil
+		}
+		if dst.Type != Struct {
+			return fmt.Errorf("expected Struct, got %s", dst.Type)
+		}
+		dst.Data.(*VMStruct).Remove(key)
+
+	case UNPACK:
+		src, dst := reg(), reg()
+		if r.err != nil {
+			return nil
+		}
+		b, err := src.toBytes()
+		if err != nil {
+			return err
+		}
+		reader := io.NewBinReaderFromBuf(b)
+		var obj VMObject
+		obj.Deserialize(reader)
+		if reader.Err != nil {
+			return reader.Err
+		}
+		*dst = obj
+
+	case PACK:
+		src, dst := reg(), reg()
+		if r.err != nil {
+			return nil
+		}
+		b := io.Serialize(src)
+		if b == nil {
+			return fmt.Errorf("cannot pack %s", src.Type)
+		}
+		*dst = *NewBytes(b)
+
+	default:
+		return fmt.Errorf("unsupported opcode %s", opcode)
+	}
+
+	return nil
+}
+
+func (r *scriptReader) jump(target int) error {
+	if target >= len(r.script) {
+		return fmt.Errorf("invalid jump offset %d", target)
+	}
+
+	r.ip = target
+	return nil
+}
+
+func validateKey(key *VMObject) error {
+	switch key.Type {
+	case None, Struct, Object:
+		return fmt.Errorf("cannot use value of type %s as key for struct field", key.Type)
+	}
+
+	return nil
+}
+
+func castTo(src *VMObject, vmType VMType) (*VMObject, error) {
+	if src.Type == vmType {
+		var obj VMObject
+		obj.Copy(src)
+		return &obj, nil
+	}
+
+	switch vmType {
+	case None:
+		return &VMObject{}, nil
+
+	case String:
+		s, err := src.toString()
+		if err != nil {
+			return nil, err
+		}
+		return NewString(s), nil
+
+	case Number:
+		n, err := src.toNumber()
+		if err != nil {
+			return nil, err
+		}
+		return NewNumber(n), nil
+
+	case Bool:
+		b, err := src.toBool()
+		if err != nil {
+			return nil, err
+		}
+		return NewBool(b), nil
+
+	case Bytes:
+		b, err := src.toBytes()
+		if err != nil {
+			return nil, err
+		}
+		return NewBytes(b), nil
+
+	case Timestamp, Enum:
+		if src.Type != Number && src.Type != Timestamp && src.Type != Enum {
+			break
+		}
+		n, _ := src.toNumber()
+		if !n.IsUint64() || n.Uint64() > 0xFFFFFFFF {
+			return nil, fmt.Errorf("value %s is out of range for %s", n, vmType)
+		}
+		if vmType == Enum {
+			return NewEnum(uint32(n.Uint64())), nil
+		}
+		return NewTimestamp(types.Timestamp{Value: uint32(n.Uint64())}), nil
+
+	case Object:
+		a, err := src.AsAddress()
+		if err != nil {
+			return nil, err
+		}
+		return NewAddress(a), nil
+	}
+
+	return nil, fmt.Errorf("cannot cast %s to %s", src.Type, vmType)
+}
+
+func concat(a, b, dst *VMObject) error {
+	if a.IsEmpty() {
+		dst.Copy(b)
+		return nil
+	}
+
+	if b.IsEmpty() {
+		dst.Copy(a)
+		return nil
+	}
+
+	if a.Type != b.Type {
+		return fmt.Errorf("cannot concatenate %s and %s", a.Type, b.Type)
+	}
+
+	x, err := a.toBytes()
+	if err != nil {
+		return err
+	}
+
+	y, err := b.toBytes()
+	if err != nil {
+		return err
+	}
+
+	result := make([]byte, 0, len(x)+len(y))
+	result = append(result, x...)
+	result = append(result, y...)
+
+	var obj VMObject
+	if err := obj.SetValueE(result, a.Type); err != nil {
+		return err
+	}
+	*dst = obj
+
+	return nil
+}
+
+func slice(src, dst *VMObject, index, length int, resultType VMType) error {
+	b, err := src.toBytes()
+	if err != nil {
+		return err
+	}
+
+	if index < 0 || length < 0 || index+length > len(b) {
+		return fmt.Errorf("range %d..%d is out of bounds (length %d)", index, index+length, len(b))
+	}
+
+	result := make([]byte, length)
+	copy(result, b[index:index+length])
+
+	var obj VMObject
+	if err := obj.SetValueE(result, resultType); err != nil {
+		return err
+	}
+	*dst = obj
+
+	return nil
+}
+
+func numbers(a, b *VMObject) (*big.Int, *big.Int, error) {
+	x, err := a.toNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	y, err := b.toNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return x, y, nil
+}
+
+func logical(opcode Opcode, a, b, dst *VMObject) error {
+	if a.Type == Bool && b.Type == Bool {
+		x, y := a.Data.(bool), b.Data.(bool)
+		var result bool
+		switch opcode {
+		case AND:
+			result = x && y
+		case OR:
+			result = x || y
+		case XOR:
+			result = x != y
+		}
+		*dst = *NewBool(result)
+		return nil
+	}
+
+	if a.Type == Number && b.Type == Number {
+		x, y, _ := numbers(a, b)
+		switch opcode {
+		case AND:
+			x.And(x, y)
+		case OR:
+			x.Or(x, y)
+		case XOR:
+			x.Xor(x, y)
+		}
+		*dst = *NewNumber(x)
+		return nil
+	}
+
+	return fmt.Errorf("invalid operand types %s and %s", a.Type, b.Type)
+}
+
+func arithmetic(opcode Opcode, a, b, dst *VMObject) error {
+	if opcode == ADD && a.Type == String {
+		y, err := b.toString()
+		if err != nil {
+			return err
+		}
+		*dst = *NewString(a.Data.(string) + y)
+		return nil
+	}
+
+	x, y, err := numbers(a, b)
+	if err != nil {
+		return err
+	}
+
+	switch opcode {
+	case ADD:
+		x.Add(x, y)
+	case SUB:
+		x.Sub(x, y)
+	case MUL:
+		x.Mul(x, y)
+	case DIV, MOD:
+		if y.Sign() == 0 {
+			return errors.New("division by zero")
+		}
+		// Node uses C# BigInteger semantics, which truncates towards zero
+		if opcode == DIV {
+			x.Quo(x, y)
+		} else {
+			x.Rem(x, y)
+		}
+	case SHL, SHR:
+		if !y.IsInt64() || y.Sign() < 0 || y.Int64() > 1024 {
+			return fmt.Errorf("invalid shift %s", y)
+		}
+		if opcode == SHL {
+			x.Lsh(x, uint(y.Int64()))
+		} else {
+			x.Rsh(x, uint(y.Int64()))
+		}
+	case MIN:
+		if y.Cmp(x) < 0 {
+			x = y
+		}
+	case MAX:
+		if y.Cmp(x) > 0 {
+			x = y
+		}
+	case POW:
+		if y.Sign() < 0 || !y.IsInt64() || y.Int64() > 1024 {
+			return fmt.Errorf("invalid exponent %s", y)
+		}
+		x.Exp(x, y, nil)
+	}
+
+	*dst = *NewNumber(x)
+	return nil
+}
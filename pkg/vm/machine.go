@@ -0,0 +1,277 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+)
+
+// ExecutionState describes the state of the virtual machine
+type ExecutionState byte
+
+const (
+	Running ExecutionState = iota
+	Break
+	Fault
+	Halt
+)
+
+var executionStateLookup = map[ExecutionState]string{
+	Running: `Running`,
+	Break:   `Break`,
+	Fault:   `Fault`,
+	Halt:    `Halt`,
+}
+
+func (s ExecutionState) String() string {
+	return executionStateLookup[s]
+}
+
+// MaxRegisterCount is the number of registers available to a script frame
+const MaxRegisterCount = 32
+
+// MaxContextDepth is the max number of nested contexts, it bounds recursion of SWITCH
+const MaxContextDepth = 64
+
+var (
+	ErrGasLimitExceeded  = errors.New("gas limit exceeded")
+	ErrStepLimitExceeded = errors.New("step limit exceeded")
+	ErrStackUnderflow    = errors.New("stack underflow")
+	ErrContextDepth      = errors.New("max context depth exceeded")
+)
+
+// ThrowError is returned when script executes THROW instruction
+type ThrowError struct {
+	Message string
+}
+
+func (e *ThrowError) Error() string {
+	return "script exception: " + e.Message
+}
+
+// ExecutionError describes where execution of a script failed
+type ExecutionError struct {
+	Context string
+	Offset  int
+	Opcode  Opcode
+	Err     error
+}
+
+func (e *ExecutionError) Error() string {
+	return fmt.Sprintf("%s at %s:%d: %s", e.Opcode, e.Context, e.Offset, e.Err.Error())
+}
+
+func (e *ExecutionError) Unwrap() error {
+	return e.Err
+}
+
+// InteropHandler implements an EXTCALL method or a method of a native contract.
+// Arguments are popped from the machine stack and results are pushed back onto it.
+type InteropHandler func(machine *VirtualMachine) error
+
+// VirtualMachine executes Phantasma VM scripts locally, without a node.
+// EXTCALL methods and contracts available through CTX/SWITCH are provided by registering handlers.
+type VirtualMachine struct {
+	// GasLimit is the max amount of gas execution can consume, 0 means unlimited
+	GasLimit uint64
+	// StepLimit is the max number of instructions to execute, 0 means unlimited
+	StepLimit int
+
+	GasUsed uint64
+	Steps   int
+	State   ExecutionState
+
+	entry        *ScriptContext
+	stack        []VMObject
+	interops     map[string]InteropHandler
+	contexts     map[string]ExecutionContext
+	contextStack []ExecutionContext
+}
+
+// NewVirtualMachine creates a machine which will execute given script as an entry context
+func NewVirtualMachine(script []byte) *VirtualMachine {
+	return &VirtualMachine{
+		entry:    NewScriptContext("entry", script),
+		stack:    []VMObject{},
+		interops: make(map[string]InteropHandler),
+		contexts: make(map[string]ExecutionContext),
+	}
+}
+
+// RegisterInterop makes handler available to scripts through EXTCALL with given method name
+func (m *VirtualMachine) RegisterInterop(method string, handler InteropHandler) {
+	m.interops[method] = handler
+}
+
+// RegisterContext makes context available to scripts through CTX instruction
+func (m *VirtualMachine) RegisterContext(context ExecutionContext) {
+	m.contexts[context.Name()] = context
+}
+
+// FindContext returns registered context with the given name
+func (m *VirtualMachine) FindContext(name string) (ExecutionContext, bool) {
+	context, ok := m.contexts[name]
+	return context, ok
+}
+
+// Execute runs the entry script until it halts or faults
+func (m *VirtualMachine) Execute() (state ExecutionState, err error) {
+	m.State = Running
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("vm panic: %v", r)
+		}
+
+		if err != nil {
+			m.State = Fault
+		} else {
+			m.State = Halt
+		}
+		state = m.State
+	}()
+
+	err = m.SwitchContext(m.entry)
+	return
+}
+
+// SwitchContext executes given context and returns to the current one once it finishes
+func (m *VirtualMachine) SwitchContext(context ExecutionContext) error {
+	if len(m.contextStack) >= MaxContextDepth {
+		return ErrContextDepth
+	}
+
+	m.contextStack = append(m.contextStack, context)
+	defer func() {
+		m.contextStack = m.contextStack[:len(m.contextStack)-1]
+	}()
+
+	return context.Execute(m)
+}
+
+// CurrentContext returns context which is being executed
+func (m *VirtualMachine) CurrentContext() ExecutionContext {
+	if len(m.contextStack) == 0 {
+		return nil
+	}
+
+	return m.contextStack[len(m.contextStack)-1]
+}
+
+// PreviousContext returns context which switched to the current one
+func (m *VirtualMachine) PreviousContext() ExecutionContext {
+	if len(m.contextStack) < 2 {
+		return nil
+	}
+
+	return m.contextStack[len(m.contextStack)-2]
+}
+
+// EntryContext returns context of the script machine was created with
+func (m *VirtualMachine) EntryContext() ExecutionContext {
+	return m.entry
+}
+
+// ConsumeGas adds amount to used gas and fails if gas limit is exceeded
+func (m *VirtualMachine) ConsumeGas(amount uint64) error {
+	m.GasUsed += amount
+	if m.GasLimit > 0 && m.GasUsed > m.GasLimit {
+		return ErrGasLimitExceeded
+	}
+
+	return nil
+}
+
+// InstructionCost returns amount of gas consumed by execution of a single instruction
+func InstructionCost(opcode Opcode) uint64 {
+	switch opcode {
+	case GET, PUT, CALL, LOAD:
+		return 5
+	case EXTCALL, CTX:
+		return 10
+	case SWITCH:
+		return 100
+	case NOP, RET:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// Stack returns a copy of the stack, bottom item first
+func (m *VirtualMachine) Stack() []VMObject {
+	result := make([]VMObject, len(m.stack))
+	copy(result, m.stack)
+	return result
+}
+
+// Push puts a copy of the object on top of the stack
+func (m *VirtualMachine) Push(obj *VMObject) {
+	var temp VMObject
+	temp.Copy(obj)
+	m.stack = append(m.stack, temp)
+}
+
+// Pop removes and returns object from the top of the stack
+func (m *VirtualMachine) Pop() (*VMObject, error) {
+	if len(m.stack) == 0 {
+		return nil, ErrStackUnderflow
+	}
+
+	obj := m.stack[len(m.stack)-1]
+	m.stack = m.stack[:len(m.stack)-1]
+	return &obj, nil
+}
+
+// PopNumber pops an object and converts it to a number
+func (m *VirtualMachine) PopNumber() (*big.Int, error) {
+	obj, err := m.Pop()
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.toNumber()
+}
+
+// PopString pops an object and converts it to a string
+func (m *VirtualMachine) PopString() (string, error) {
+	obj, err := m.Pop()
+	if err != nil {
+		return "", err
+	}
+
+	return obj.toString()
+}
+
+// PopBool pops an object and converts it to a bool
+func (m *VirtualMachine) PopBool() (bool, error) {
+	obj, err := m.Pop()
+	if err != nil {
+		return false, err
+	}
+
+	return obj.toBool()
+}
+
+// PopBytes pops an object and converts it to a byte array
+func (m *VirtualMachine) PopBytes() ([]byte, error) {
+	obj, err := m.Pop()
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.toBytes()
+}
+
+// PopAddress pops an object and converts it to an address.
+// Addresses are accepted as objects, text or bytes, with or without length prefix.
+func (m *VirtualMachine) PopAddress() (cryptography.Address, error) {
+	obj, err := m.Pop()
+	if err != nil {
+		return cryptography.Address{}, err
+	}
+
+	return obj.AsAddress()
+}
@@ -0,0 +1,298 @@
+package vm_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assemble(t *testing.T, source string) []byte {
+	script, err := scriptbuilder.Assemble(source)
+	require.Nil(t, err)
+	return script
+}
+
+func execute(t *testing.T, source string) (*vm.VirtualMachine, vm.ExecutionState, error) {
+	machine := vm.NewVirtualMachine(assemble(t, source))
+	state, err := machine.Execute()
+	return machine, state, err
+}
+
+func TestVMArithmeticLoop(t *testing.T) {
+	// Sums numbers 1..10
+	machine, state, err := execute(t, `
+		LOAD r0, 0
+		LOAD r1, 0
+		LOAD r2, 10
+	loop:
+		INC r1
+		ADD r0, r1, r0
+		LT r1, r2, r3
+		JMPIF r3, loop
+		PUSH r0
+		RET
+	`)
+
+	require.Nil(t, err)
+	assert.Equal(t, vm.Halt, state)
+
+	stack := machine.Stack()
+	require.Len(t, stack, 1)
+	assert.Equal(t, "55", stack[0].AsString())
+}
+
+func TestVMNumbers(t *testing.T) {
+	machine, _, err := execute(t, `
+		LOAD r0, -7
+		LOAD r1, 2
+		DIV r0, r1, r2
+		PUSH r2
+		MOD r0, r1, r2
+		PUSH r2
+		POW r1, r1, r2
+		PUSH r2
+		SHL r1, r1, r2
+		PUSH r2
+		ABS r0, r2
+		PUSH r2
+		MAX r0, r1, r2
+		PUSH r2
+		LOAD r3, 6
+		AND r3, r1, r2
+		PUSH r2
+	`)
+	require.Nil(t, err)
+
+	var results []string
+	for _, obj := range machine.Stack() {
+		results = append(results, obj.AsString())
+	}
+	assert.Equal(t, []string{"-3", "-1", "4", "8", "7", "2", "2"}, results)
+}
+
+func TestVMStrings(t *testing.T) {
+	machine, _, err := execute(t, `
+		LOAD r0, "Phantasma"
+		LOAD r1, " VM"
+		CAT r0, r1, r2
+		PUSH r2
+		LEFT r0, r3, 3
+		PUSH r3
+		RIGHT r0, r3, 4
+		PUSH r3
+		RANGE r0, r3, 1, 3
+		PUSH r3
+		SIZE r2, r3
+		PUSH r3
+		LOAD r4, 5
+		ADD r0, r4, r3
+		PUSH r3
+		EQUAL r0, r0, r3
+		PUSH r3
+	`)
+	require.Nil(t, err)
+
+	var results []string
+	for _, obj := range machine.Stack() {
+		results = append(results, obj.AsString())
+	}
+	assert.Equal(t, []string{"Phantasma VM", "Pha", "asma", "han", "12", "Phantasma5", "true"}, results)
+}
+
+func TestVMStructs(t *testing.T) {
+	machine, _, err := execute(t, `
+		LOAD r1, 0
+		LOAD r2, "first"
+		PUT r2, r0, r1
+		LOAD r1, 1
+		LOAD r2, "second"
+		PUT r2, r0, r1
+		LOAD r1, "name"
+		LOAD r2, "third"
+		PUT r2, r0, r1
+		COUNT r0, r3
+		PUSH r3
+		LOAD r1, 1
+		GET r0, r4, r1
+		PUSH r4
+		REMOVE r0, r1
+		COUNT r0, r3
+		PUSH r3
+		PUSH r0
+	`)
+	require.Nil(t, err)
+
+	stack := machine.Stack()
+	require.Len(t, stack, 4)
+	assert.Equal(t, "3", stack[0].AsString())
+	assert.Equal(t, "second", stack[1].AsString())
+	assert.Equal(t, "2", stack[2].AsString())
+
+	s := stack[3].Data.(*vm.VMStruct)
+	value, ok := s.Get(vm.NewString("name"))
+	assert.True(t, ok)
+	assert.Equal(t, "third", value.AsString())
+	_, ok = s.Get(vm.NewNumber(big.NewInt(1)))
+	assert.False(t, ok)
+}
+
+func TestVMCall(t *testing.T) {
+	machine, _, err := execute(t, `
+		LOAD r0, 20
+		PUSH r0
+		CALL 4, double
+		CALL 4, double
+		RET
+	double:
+		POP r0
+		ADD r0, r0, r0
+		PUSH r0
+		RET
+	`)
+	require.Nil(t, err)
+	assert.Equal(t, "80", machine.Stack()[0].AsString())
+}
+
+func TestVMThrow(t *testing.T) {
+	machine, state, err := execute(t, `
+		LOAD r0, "something went wrong"
+		THROW r0
+	`)
+
+	assert.Equal(t, vm.Fault, state)
+	assert.Equal(t, vm.Fault, machine.State)
+
+	var throwErr *vm.ThrowError
+	require.True(t, errors.As(err, &throwErr))
+	assert.Equal(t, "something went wrong", throwErr.Message)
+
+	var executionErr *vm.ExecutionError
+	require.True(t, errors.As(err, &executionErr))
+	assert.Equal(t, vm.THROW, executionErr.Opcode)
+	assert.Equal(t, 24, executionErr.Offset)
+}
+
+func TestVMInteropsAndContexts(t *testing.T) {
+	script := scriptbuilder.BeginScript().
+		CallInterop("Runtime.Sum", 2, big.NewInt(40)).
+		CallContract("calc", "Twice", 21).
+		EndScript()
+
+	machine := vm.NewVirtualMachine(script)
+	machine.RegisterInterop("Runtime.Sum", func(m *vm.VirtualMachine) error {
+		a, err := m.PopNumber()
+		if err != nil {
+			return err
+		}
+		b, err := m.PopNumber()
+		if err != nil {
+			return err
+		}
+		m.Push(vm.NewNumber(a.Add(a, b)))
+		return nil
+	})
+	machine.RegisterContext(vm.NewNativeContext("calc", map[string]vm.InteropHandler{
+		"Twice": func(m *vm.VirtualMachine) error {
+			assert.Equal(t, "calc", m.CurrentContext().Name())
+			assert.Equal(t, "entry", m.PreviousContext().Name())
+			n, err := m.PopNumber()
+			if err != nil {
+				return err
+			}
+			m.Push(vm.NewNumber(n.Mul(n, big.NewInt(2))))
+			return nil
+		},
+	}))
+
+	state, err := machine.Execute()
+	require.Nil(t, err)
+	assert.Equal(t, vm.Halt, state)
+
+	stack := machine.Stack()
+	require.Len(t, stack, 2)
+	assert.Equal(t, "42", stack[0].AsString())
+	assert.Equal(t, "42", stack[1].AsString())
+
+	_, err = vm.NewVirtualMachine(scriptbuilder.BeginScript().CallInterop("Runtime.Unknown").EndScript()).Execute()
+	assert.ErrorContains(t, err, "unknown interop method Runtime.Unknown")
+
+	_, err = vm.NewVirtualMachine(scriptbuilder.BeginScript().CallContract("unknown", "Method").EndScript()).Execute()
+	assert.ErrorContains(t, err, "unknown context unknown")
+}
+
+func TestVMLimits(t *testing.T) {
+	script := assemble(t, `
+	loop:
+		LOAD r0, 1
+		JMP loop
+	`)
+
+	machine := vm.NewVirtualMachine(script)
+	machine.StepLimit = 100
+	state, err := machine.Execute()
+	assert.Equal(t, vm.Fault, state)
+	assert.True(t, errors.Is(err, vm.ErrStepLimitExceeded))
+	assert.Equal(t, 101, machine.Steps)
+
+	machine = vm.NewVirtualMachine(script)
+	machine.GasLimit = 60
+	state, err = machine.Execute()
+	assert.Equal(t, vm.Fault, state)
+	assert.True(t, errors.Is(err, vm.ErrGasLimitExceeded))
+	assert.Equal(t, uint64(65), machine.GasUsed)
+
+	// Context switching to itself is stopped even without a step limit
+	loop := assemble(t, `
+		LOAD r0, "loop"
+		CTX r0, r1
+		SWITCH r1
+		RET
+	`)
+	machine = vm.NewVirtualMachine(loop)
+	machine.RegisterContext(vm.NewScriptContext("loop", loop))
+	state, err = machine.Execute()
+	assert.Equal(t, vm.Fault, state)
+	assert.True(t, errors.Is(err, vm.ErrContextDepth))
+}
+
+func TestVMMove(t *testing.T) {
+	machine, state, err := execute(t, `
+		LOAD r0, "moved"
+		MOVE r0, r1
+		LOAD r2, "kept"
+		MOVE r2, r2
+		PUSH r1
+		PUSH r2
+		RET
+	`)
+
+	require.Nil(t, err)
+	assert.Equal(t, vm.Halt, state)
+
+	stack := machine.Stack()
+	require.Len(t, stack, 2)
+	assert.Equal(t, "moved", stack[0].AsString())
+	assert.Equal(t, "kept", stack[1].AsString())
+}
+
+func TestVMInvalidScripts(t *testing.T) {
+	cases := map[string][]byte{
+		"truncated":        {byte(vm.LOAD), 0, byte(vm.String), 5, 'a'},
+		"invalid register": {byte(vm.PUSH), 40},
+		"stack underflow":  {byte(vm.POP), 0},
+		"invalid jump":     {byte(vm.JMP), 0xff, 0},
+		"not a bool":       {byte(vm.JMPIF), 0, 0, 0},
+		"unknown opcode":   {200},
+	}
+
+	for name, script := range cases {
+		state, err := vm.NewVirtualMachine(script).Execute()
+		assert.Equal(t, vm.Fault, state, name)
+		assert.NotNil(t, err, name)
+	}
+}
@@ -0,0 +1,506 @@
+package vm
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/types"
+)
+
+var (
+	bigIntType    = reflect.TypeOf(big.Int{})
+	timeType      = reflect.TypeOf(time.Time{})
+	addressType   = reflect.TypeOf(cryptography.Address{})
+	vmObjectType  = reflect.TypeOf(VMObject{})
+	timestampType = reflect.TypeOf(types.Timestamp{})
+)
+
+// UnmarshalError describes a value which can't be stored into a Go value
+type UnmarshalError struct {
+	// Path to the value, like "Owner" or "Items[2].Name"
+	Path string
+	// Type of the vm object
+	VMType VMType
+	// Type of the Go value
+	GoType reflect.Type
+	// Err is an optional reason
+	Err error
+}
+
+func (e *UnmarshalError) Error() string {
+	s := fmt.Sprintf("cannot unmarshal %s into Go value of type %s", e.VMType, e.GoType)
+	if e.Path != "" {
+		s = fmt.Sprintf("cannot unmarshal %s into %s of type %s", e.VMType, e.Path, e.GoType)
+	}
+	if e.Err != nil {
+		s += ": " + e.Err.Error()
+	}
+
+	return s
+}
+
+func (e *UnmarshalError) Unwrap() error {
+	return e.Err
+}
+
+// Unmarshal stores value of the vm object into the value pointed to by v.
+//
+// Structs are mapped to Go structs by field names, name can be changed with `vm:"name"` tag,
+// fields tagged with `vm:"-"` are skipped. Structs with Number keys 0..n-1 are arrays
+// and are mapped to Go slices and arrays, any other struct can be mapped to a Go map.
+// Number is mapped to *big.Int, big.Int and integer types, Timestamp to time.Time,
+// Object to cryptography.Address, Bytes to []byte. Values can be kept undecoded
+// by using VMObject or *VMObject as a field type. Struct keys missing from the object
+// leave Go fields unchanged.
+func Unmarshal(obj *VMObject, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("unmarshal expects a non-nil pointer, got %T", v)
+	}
+
+	if obj == nil {
+		obj = &VMObject{}
+	}
+
+	return unmarshalValue(obj, rv.Elem(), "")
+}
+
+func unmarshalValue(obj *VMObject, rv reflect.Value, path string) error {
+	fail := func(err error) error {
+		return &UnmarshalError{Path: path, VMType: obj.Type, GoType: rv.Type(), Err: err}
+	}
+
+	switch rv.Type() {
+	case vmObjectType:
+		var c VMObject
+		c.Copy(obj)
+		rv.Set(reflect.ValueOf(c))
+		return nil
+
+	case bigIntType:
+		n, err := obj.toNumber()
+		if err != nil {
+			return fail(nil)
+		}
+		rv.Set(reflect.ValueOf(*n))
+		return nil
+
+	case timeType:
+		switch obj.Type {
+		case Timestamp, Number, None:
+			n, _ := obj.toNumber()
+			rv.Set(reflect.ValueOf(time.Unix(n.Int64(), 0).UTC()))
+			return nil
+		}
+		return fail(nil)
+
+	case timestampType:
+		switch obj.Type {
+		case Timestamp, Number, None:
+			n, _ := obj.toNumber()
+			if !n.IsUint64() || n.Uint64() > 0xFFFFFFFF {
+				return fail(fmt.Errorf("value %s is out of range", n))
+			}
+			rv.Set(reflect.ValueOf(types.Timestamp{Value: uint32(n.Uint64())}))
+			return nil
+		}
+		return fail(nil)
+
+	case addressType:
+		if obj.Type == None {
+			rv.Set(reflect.ValueOf(cryptography.Address{}))
+			return nil
+		}
+		a, err := obj.AsAddress()
+		if err != nil {
+			return fail(err)
+		}
+		rv.Set(reflect.ValueOf(a))
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if obj.Type == None {
+			rv.Set(reflect.Zero(rv.Type()))
+			return nil
+		}
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return unmarshalValue(obj, rv.Elem(), path)
+
+	case reflect.Interface:
+		if rv.NumMethod() != 0 {
+			return fail(nil)
+		}
+		value, err := toInterface(obj)
+		if err != nil {
+			return fail(err)
+		}
+		if value == nil {
+			rv.Set(reflect.Zero(rv.Type()))
+		} else {
+			rv.Set(reflect.ValueOf(value))
+		}
+		return nil
+
+	case reflect.String:
+		if obj.Type == Struct {
+			return fail(nil)
+		}
+		s, err := obj.toString()
+		if err != nil {
+			return fail(nil)
+		}
+		rv.SetString(s)
+		return nil
+
+	case reflect.Bool:
+		b, err := obj.toBool()
+		if err != nil {
+			return fail(nil)
+		}
+		rv.SetBool(b)
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := obj.toNumber()
+		if err != nil {
+			return fail(nil)
+		}
+		if !n.IsInt64() || rv.OverflowInt(n.Int64()) {
+			return fail(fmt.Errorf("value %s is out of range", n))
+		}
+		rv.SetInt(n.Int64())
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := obj.toNumber()
+		if err != nil {
+			return fail(nil)
+		}
+		if !n.IsUint64() || rv.OverflowUint(n.Uint64()) {
+			return fail(fmt.Errorf("value %s is out of range", n))
+		}
+		rv.SetUint(n.Uint64())
+		return nil
+
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 && obj.Type != Struct {
+			b, err := obj.toBytes()
+			if err != nil {
+				return fail(nil)
+			}
+			rv.SetBytes(append([]byte{}, b...))
+			return nil
+		}
+
+		if obj.Type == None {
+			rv.Set(reflect.Zero(rv.Type()))
+			return nil
+		}
+
+		items, ok := arrayItems(obj)
+		if !ok {
+			return fail(nil)
+		}
+		slice := reflect.MakeSlice(rv.Type(), len(items), len(items))
+		for i := range items {
+			if err := unmarshalValue(&items[i], slice.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		rv.Set(slice)
+		return nil
+
+	case reflect.Array:
+		items, ok := arrayItems(obj)
+		if !ok {
+			return fail(nil)
+		}
+		if len(items) != rv.Len() {
+			return fail(fmt.Errorf("array has %d elements", len(items)))
+		}
+		for i := range items {
+			if err := unmarshalValue(&items[i], rv.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Map:
+		if obj.Type == None {
+			rv.Set(reflect.Zero(rv.Type()))
+			return nil
+		}
+		if obj.Type != Struct {
+			return fail(nil)
+		}
+
+		fields := obj.Data.(*VMStruct).Fields
+		m := reflect.MakeMapWithSize(rv.Type(), len(fields))
+		for i := range fields {
+			key := reflect.New(rv.Type().Key()).Elem()
+			if err := unmarshalValue(&fields[i].Key, key, path+"[key]"); err != nil {
+				return err
+			}
+
+			value := reflect.New(rv.Type().Elem()).Elem()
+			if err := unmarshalValue(&fields[i].Value, value, fmt.Sprintf("%s[%v]", path, key)); err != nil {
+				return err
+			}
+			m.SetMapIndex(key, value)
+		}
+		rv.Set(m)
+		return nil
+
+	case reflect.Struct:
+		if obj.Type != Struct {
+			return fail(nil)
+		}
+
+		s := obj.Data.(*VMStruct)
+		t := rv.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name, ok := fieldName(t.Field(i))
+			if !ok {
+				continue
+			}
+
+			value, found := s.Get(NewString(name))
+			if !found {
+				continue
+			}
+
+			fieldPath := t.Field(i).Name
+			if path != "" {
+				fieldPath = path + "." + fieldPath
+			}
+			if err := unmarshalValue(&value, rv.Field(i), fieldPath); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fail(nil)
+}
+
+// arrayItems returns values of a struct with Number keys 0..n-1
+func arrayItems(obj *VMObject) ([]VMObject, bool) {
+	if obj.Type != Struct {
+		return nil, false
+	}
+
+	s := obj.Data.(*VMStruct)
+	items := make([]VMObject, s.Len())
+	for i := range items {
+		value, ok := s.Get(NewNumber(big.NewInt(int64(i))))
+		if !ok {
+			return nil, false
+		}
+		items[i] = value
+	}
+
+	return items, true
+}
+
+// toInterface converts object to a natural Go representation
+func toInterface(obj *VMObject) (interface{}, error) {
+	switch obj.Type {
+	case None:
+		return nil, nil
+	case Number, Enum:
+		return obj.toNumber()
+	case String:
+		return obj.Data.(string), nil
+	case Bool:
+		return obj.Data.(bool), nil
+	case Bytes:
+		return obj.Data.([]byte), nil
+	case Timestamp:
+		return time.Unix(int64(obj.Data.(types.Timestamp).Value), 0).UTC(), nil
+	case Object:
+		if a, ok := obj.asAddress(); ok {
+			return a, nil
+		}
+		return nil, fmt.Errorf("unsupported object %T", obj.Data)
+	case Struct:
+		if items, ok := arrayItems(obj); ok && len(items) > 0 {
+			result := make([]interface{}, len(items))
+			for i := range items {
+				value, err := toInterface(&items[i])
+				if err != nil {
+					return nil, err
+				}
+				result[i] = value
+			}
+			return result, nil
+		}
+
+		fields := obj.Data.(*VMStruct).Fields
+		result := make(map[string]interface{}, len(fields))
+		for i := range fields {
+			key, err := fields[i].Key.toString()
+			if err != nil {
+				return nil, err
+			}
+			value, err := toInterface(&fields[i].Value)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = value
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", obj.Type)
+}
+
+// fieldName returns struct key of a Go struct field, false is returned for skipped fields
+func fieldName(f reflect.StructField) (string, bool) {
+	if !f.IsExported() {
+		return "", false
+	}
+
+	tag := f.Tag.Get("vm")
+	if tag == "-" {
+		return "", false
+	}
+
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, true
+	}
+
+	return f.Name, true
+}
+
+// Marshal converts Go value into a vm object, see Unmarshal for the mapping of types
+func Marshal(v interface{}) (*VMObject, error) {
+	if v == nil {
+		return &VMObject{}, nil
+	}
+
+	return marshalValue(reflect.ValueOf(v), "")
+}
+
+func marshalValue(rv reflect.Value, path string) (*VMObject, error) {
+	fail := func(err error) error {
+		if path == "" {
+			return fmt.Errorf("cannot marshal Go value of type %s: %w", rv.Type(), err)
+		}
+		return fmt.Errorf("cannot marshal %s of type %s: %w", path, rv.Type(), err)
+	}
+
+	switch rv.Type() {
+	case vmObjectType:
+		obj := rv.Interface().(VMObject)
+		var c VMObject
+		c.Copy(&obj)
+		return &c, nil
+	case bigIntType:
+		n := rv.Interface().(big.Int)
+		return NewNumber(&n), nil
+	case timeType:
+		t := rv.Interface().(time.Time)
+		if t.Unix() < 0 || t.Unix() > 0xFFFFFFFF {
+			return nil, fail(fmt.Errorf("time %s is out of timestamp range", t))
+		}
+		return NewTimestamp(types.Timestamp{Value: uint32(t.Unix())}), nil
+	case timestampType:
+		return NewTimestamp(rv.Interface().(types.Timestamp)), nil
+	case addressType:
+		return NewAddress(rv.Interface().(cryptography.Address)), nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return &VMObject{}, nil
+		}
+		return marshalValue(rv.Elem(), path)
+
+	case reflect.String:
+		return NewString(rv.String()), nil
+
+	case reflect.Bool:
+		return NewBool(rv.Bool()), nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return NewNumber(big.NewInt(rv.Int())), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return NewNumber(new(big.Int).SetUint64(rv.Uint())), nil
+
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return NewBytes(b), nil
+		}
+
+		s := NewVMStruct()
+		for i := 0; i < rv.Len(); i++ {
+			value, err := marshalValue(rv.Index(i), fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			s.Set(*NewNumber(big.NewInt(int64(i))), *value)
+		}
+		return &VMObject{Type: Struct, Data: s}, nil
+
+	case reflect.Map:
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+
+		s := NewVMStruct()
+		for _, k := range keys {
+			key, err := marshalValue(k, path+"[key]")
+			if err != nil {
+				return nil, err
+			}
+			switch key.Type {
+			case None, Struct, Object:
+				return nil, fail(fmt.Errorf("%s can't be used as a struct key", key.Type))
+			}
+
+			value, err := marshalValue(rv.MapIndex(k), fmt.Sprintf("%s[%v]", path, k))
+			if err != nil {
+				return nil, err
+			}
+			s.Set(*key, *value)
+		}
+		return &VMObject{Type: Struct, Data: s}, nil
+
+	case reflect.Struct:
+		s := NewVMStruct()
+		t := rv.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name, ok := fieldName(t.Field(i))
+			if !ok {
+				continue
+			}
+
+			fieldPath := t.Field(i).Name
+			if path != "" {
+				fieldPath = path + "." + fieldPath
+			}
+			value, err := marshalValue(rv.Field(i), fieldPath)
+			if err != nil {
+				return nil, err
+			}
+			s.Set(*NewString(name), *value)
+		}
+		return &VMObject{Type: Struct, Data: s}, nil
+	}
+
+	return nil, fail(fmt.Errorf("unsupported type"))
+}
@@ -0,0 +1,128 @@
+package vm_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/types"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tokenSeries struct {
+	ID        *big.Int `vm:"seriesID"`
+	MaxSupply uint64
+	Created   time.Time
+	Burnable  bool
+	Script    []byte
+}
+
+type tokenInfo struct {
+	Symbol   string `vm:"symbol"`
+	Owner    cryptography.Address
+	Decimals int
+	Series   []tokenSeries
+	Tags     map[string]string
+	Extra    vm.VMObject
+	Ignored  string `vm:"-"`
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	owner, err := cryptography.FromString(testAddress)
+	require.Nil(t, err)
+
+	info := tokenInfo{
+		Symbol:   "CROWN",
+		Owner:    owner,
+		Decimals: 0,
+		Series: []tokenSeries{
+			{ID: big.NewInt(1), MaxSupply: 100, Created: time.Unix(1623519055, 0).UTC(), Burnable: true, Script: []byte{1, 2}},
+			{ID: big.NewInt(2), MaxSupply: 0, Created: time.Unix(0, 0).UTC(), Script: []byte{}},
+		},
+		Tags:    map[string]string{"type": "crown", "rarity": "epic"},
+		Extra:   *vm.NewEnum(3),
+		Ignored: "ignored",
+	}
+
+	obj, err := vm.Marshal(info)
+	require.Nil(t, err)
+	require.Equal(t, vm.Struct, obj.Type)
+
+	symbol, ok := obj.Data.(*vm.VMStruct).Get(vm.NewString("symbol"))
+	require.True(t, ok)
+	assert.Equal(t, "CROWN", symbol.AsString())
+	_, ok = obj.Data.(*vm.VMStruct).Get(vm.NewString("Ignored"))
+	assert.False(t, ok)
+
+	// decode object as it would be returned by the node
+	decoded := io.Deserialize[*vm.VMObject](io.Serialize(obj))
+
+	var result tokenInfo
+	require.Nil(t, vm.Unmarshal(decoded, &result))
+	assert.Equal(t, info.Symbol, result.Symbol)
+	assert.Equal(t, info.Owner.String(), result.Owner.String())
+	assert.Equal(t, info.Series, result.Series)
+	assert.Equal(t, info.Tags, result.Tags)
+	assert.True(t, info.Extra.Equals(&result.Extra))
+	assert.Empty(t, result.Ignored)
+
+	var generic interface{}
+	require.Nil(t, vm.Unmarshal(decoded, &generic))
+	m := generic.(map[string]interface{})
+	assert.Equal(t, "CROWN", m["symbol"])
+	assert.Len(t, m["Series"], 2)
+}
+
+func TestUnmarshalScalars(t *testing.T) {
+	var n *big.Int
+	require.Nil(t, vm.Unmarshal(vm.NewString("12345"), &n))
+	assert.Equal(t, big.NewInt(12345), n)
+
+	var ts types.Timestamp
+	require.Nil(t, vm.Unmarshal(vm.NewTimestamp(types.Timestamp{Value: 42}), &ts))
+	assert.Equal(t, uint32(42), ts.Value)
+
+	var small int8
+	err := vm.Unmarshal(vm.NewNumber(big.NewInt(1000)), &small)
+	var unmarshalErr *vm.UnmarshalError
+	require.True(t, errors.As(err, &unmarshalErr))
+	assert.Equal(t, vm.Number, unmarshalErr.VMType)
+
+	var address cryptography.Address
+	require.Nil(t, vm.Unmarshal(vm.NewString(testAddress), &address))
+	assert.Equal(t, testAddress, address.String())
+
+	var arr [2]string
+	items, err := vm.Marshal([]string{"a", "b"})
+	require.Nil(t, err)
+	require.Nil(t, vm.Unmarshal(items, &arr))
+	assert.Equal(t, [2]string{"a", "b"}, arr)
+
+	assert.NotNil(t, vm.Unmarshal(items, n))
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	fields := vm.NewVMStruct()
+	fields.Set(*vm.NewString("Series"), *vm.NewString("not an array"))
+
+	var info tokenInfo
+	err := vm.Unmarshal(&vm.VMObject{Type: vm.Struct, Data: fields}, &info)
+	assert.EqualError(t, err, "cannot unmarshal String into Series of type []vm_test.tokenSeries")
+
+	series := vm.NewVMStruct()
+	series.Set(*vm.NewString("Created"), *vm.NewBool(true))
+	items := vm.NewVMStruct()
+	items.Set(*vm.NewNumber(big.NewInt(0)), vm.VMObject{Type: vm.Struct, Data: series})
+	fields.Set(*vm.NewString("Series"), vm.VMObject{Type: vm.Struct, Data: items})
+
+	err = vm.Unmarshal(&vm.VMObject{Type: vm.Struct, Data: fields}, &info)
+	assert.EqualError(t, err, "cannot unmarshal Bool into Series[0].Created of type time.Time")
+
+	_, err = vm.Marshal(map[string]interface{}{"f": 1.5})
+	assert.NotNil(t, err)
+}
@@ -1,6 +1,10 @@
 package vm
 
-//go:generate stringer -type=Opcode -linecomment
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // Opcode represents a single operation code for the Phantasma virtual machine.
 type Opcode byte
@@ -85,3 +89,164 @@ const (
 
 	EVM = 255 // TODO check this one
 )
+
+var opcodeLookup = map[Opcode]string{
+	NOP:     `NOP`,
+	MOVE:    `MOVE`,
+	COPY:    `COPY`,
+	PUSH:    `PUSH`,
+	POP:     `POP`,
+	SWAP:    `SWAP`,
+	CALL:    `CALL`,
+	EXTCALL: `EXTCALL`,
+	JMP:     `JMP`,
+	JMPIF:   `JMPIF`,
+	JMPNOT:  `JMPNOT`,
+	RET:     `RET`,
+	THROW:   `THROW`,
+	LOAD:    `LOAD`,
+	CAST:    `CAST`,
+	CAT:     `CAT`,
+	RANGE:   `RANGE`,
+	LEFT:    `LEFT`,
+	RIGHT:   `RIGHT`,
+	SIZE:    `SIZE`,
+	COUNT:   `COUNT`,
+	NOT:     `NOT`,
+	AND:     `AND`,
+	OR:      `OR`,
+	XOR:     `XOR`,
+	EQUAL:   `EQUAL`,
+	LT:      `LT`,
+	GT:      `GT`,
+	LTE:     `LTE`,
+	GTE:     `GTE`,
+	INC:     `INC`,
+	DEC:     `DEC`,
+	SIGN:    `SIGN`,
+	NEGATE:  `NEGATE`,
+	ABS:     `ABS`,
+	ADD:     `ADD`,
+	SUB:     `SUB`,
+	MUL:     `MUL`,
+	DIV:     `DIV`,
+	MOD:     `MOD`,
+	SHL:     `SHL`,
+	SHR:     `SHR`,
+	MIN:     `MIN`,
+	MAX:     `MAX`,
+	POW:     `POW`,
+	CTX:     `CTX`,
+	SWITCH:  `SWITCH`,
+	PUT:     `PUT`,
+	GET:     `GET`,
+	CLEAR:   `CLEAR`,
+	UNPACK:  `UNPACK`,
+	PACK:    `PACK`,
+	DEBUG:   `DEBUG`,
+	SUBSTR:  `SUBSTR`,
+	REMOVE:  `REMOVE`,
+	EVM:     `EVM`,
+}
+
+func (o Opcode) String() string {
+	if s, ok := opcodeLookup[o]; ok {
+		return s
+	}
+
+	return "OP_" + strconv.Itoa(int(o))
+}
+
+// ParseOpcode returns opcode for given mnemonic, mnemonic is case-insensitive
+func ParseOpcode(mnemonic string) (Opcode, error) {
+	mnemonic = strings.ToUpper(mnemonic)
+	for o, s := range opcodeLookup {
+		if s == mnemonic {
+			return o, nil
+		}
+	}
+
+	return NOP, fmt.Errorf("unknown opcode %s", mnemonic)
+}
+
+// OperandKind describes how a single instruction operand is encoded in a script
+type OperandKind byte
+
+const (
+	// RegisterOperand is a register index, encoded as a single byte
+	RegisterOperand OperandKind = iota
+	// CountOperand is a plain byte, used by CALL for the number of registers
+	CountOperand
+	// TypeOperand is a vm.VMType, encoded as a single byte
+	TypeOperand
+	// OffsetOperand is an absolute script offset, encoded as uint16 (LE)
+	OffsetOperand
+	// VarIntOperand is a variable-length encoded integer
+	VarIntOperand
+	// ValueOperand is a LOAD payload: VMType byte followed by variable-length byte array
+	ValueOperand
+)
+
+var opcodeOperands = map[Opcode][]OperandKind{
+	NOP:     {},
+	MOVE:    {RegisterOperand, RegisterOperand},
+	COPY:    {RegisterOperand, RegisterOperand},
+	PUSH:    {RegisterOperand},
+	POP:     {RegisterOperand},
+	SWAP:    {RegisterOperand, RegisterOperand},
+	CALL:    {CountOperand, OffsetOperand},
+	EXTCALL: {RegisterOperand},
+	JMP:     {OffsetOperand},
+	JMPIF:   {RegisterOperand, OffsetOperand},
+	JMPNOT:  {RegisterOperand, OffsetOperand},
+	RET:     {},
+	THROW:   {RegisterOperand},
+	LOAD:    {RegisterOperand, ValueOperand},
+	CAST:    {RegisterOperand, RegisterOperand, TypeOperand},
+	CAT:     {RegisterOperand, RegisterOperand, RegisterOperand},
+	RANGE:   {RegisterOperand, RegisterOperand, VarIntOperand, VarIntOperand},
+	LEFT:    {RegisterOperand, RegisterOperand, VarIntOperand},
+	RIGHT:   {RegisterOperand, RegisterOperand, VarIntOperand},
+	SIZE:    {RegisterOperand, RegisterOperand},
+	COUNT:   {RegisterOperand, RegisterOperand},
+	NOT:     {RegisterOperand, RegisterOperand},
+	AND:     {RegisterOperand, RegisterOperand, RegisterOperand},
+	OR:      {RegisterOperand, RegisterOperand, RegisterOperand},
+	XOR:     {RegisterOperand, RegisterOperand, RegisterOperand},
+	EQUAL:   {RegisterOperand, RegisterOperand, RegisterOperand},
+	LT:      {RegisterOperand, RegisterOperand, RegisterOperand},
+	GT:      {RegisterOperand, RegisterOperand, RegisterOperand},
+	LTE:     {RegisterOperand, RegisterOperand, RegisterOperand},
+	GTE:     {RegisterOperand, RegisterOperand, RegisterOperand},
+	INC:     {RegisterOperand},
+	DEC:     {RegisterOperand},
+	SIGN:    {RegisterOperand, RegisterOperand},
+	NEGATE:  {RegisterOperand, RegisterOperand},
+	ABS:     {RegisterOperand, RegisterOperand},
+	ADD:     {RegisterOperand, RegisterOperand, RegisterOperand},
+	SUB:     {RegisterOperand, RegisterOperand, RegisterOperand},
+	MUL:     {RegisterOperand, RegisterOperand, RegisterOperand},
+	DIV:     {RegisterOperand, RegisterOperand, RegisterOperand},
+	MOD:     {RegisterOperand, RegisterOperand, RegisterOperand},
+	SHL:     {RegisterOperand, RegisterOperand, RegisterOperand},
+	SHR:     {RegisterOperand, RegisterOperand, RegisterOperand},
+	MIN:     {RegisterOperand, RegisterOperand, RegisterOperand},
+	MAX:     {RegisterOperand, RegisterOperand, RegisterOperand},
+	POW:     {RegisterOperand, RegisterOperand, RegisterOperand},
+	CTX:     {RegisterOperand, RegisterOperand},
+	SWITCH:  {RegisterOperand},
+	PUT:     {RegisterOperand, RegisterOperand, RegisterOperand},
+	GET:     {RegisterOperand, RegisterOperand, RegisterOperand},
+	CLEAR:   {RegisterOperand},
+	UNPACK:  {RegisterOperand, RegisterOperand},
+	PACK:    {RegisterOperand, RegisterOperand},
+	DEBUG:   {},
+	SUBSTR:  {RegisterOperand, RegisterOperand, VarIntOperand, VarIntOperand},
+	REMOVE:  {RegisterOperand, RegisterOperand},
+}
+
+// Operands returns encoding layout of opcode operands, false is returned for opcodes which are not supported
+func (o Opcode) Operands() ([]OperandKind, bool) {
+	operands, ok := opcodeOperands[o]
+	return operands, ok
+}
@@ -0,0 +1,403 @@
+package scriptbuilder
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+)
+
+// AssemblerError describes an error found in assembly source, Line is 1-based
+type AssemblerError struct {
+	Line int
+	Err  error
+}
+
+func (e *AssemblerError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err.Error())
+}
+
+func (e *AssemblerError) Unwrap() error {
+	return e.Err
+}
+
+// Assemble parses assembly source into script bytes. RET is not appended automatically.
+//
+// Every line has a form of
+//
+//	[label:] [MNEMONIC [operand, ...]] [; comment]
+//
+// Operands are registers (r0..r255), VM types (Number, String, ...), unsigned integers,
+// jump targets (label name or absolute offset as @123) and LOAD literals:
+//
+//	"text"              String
+//	123, -5             Number
+//	true, false         Bool
+//	0x0102              Bytes
+//	enum(3)             Enum
+//	time(1623519055)    Timestamp
+//	raw(Type, 0x0102)   any type, raw bytes
+//
+// Output of vm.DisassembleToString() is a valid input for Assemble().
+func Assemble(source string) ([]byte, error) {
+	sb := BeginScript()
+
+	labelLines := make(map[string]int)
+	referenceLines := make(map[string]int)
+
+	for n, line := range strings.Split(source, "\n") {
+		lineNumber := n + 1
+
+		line = strings.TrimSpace(stripComment(line))
+
+		label, rest, err := splitLabel(line)
+		if err != nil {
+			return nil, &AssemblerError{lineNumber, err}
+		}
+
+		if label != "" {
+			if prev, ok := labelLines[label]; ok {
+				return nil, &AssemblerError{lineNumber, fmt.Errorf("label %s already defined on line %d", label, prev)}
+			}
+			labelLines[label] = lineNumber
+			sb.markLabel(label)
+		}
+
+		if rest == "" {
+			continue
+		}
+
+		labels, err := sb.assembleInstruction(rest)
+		if err != nil {
+			return nil, &AssemblerError{lineNumber, err}
+		}
+
+		for _, l := range labels {
+			if _, ok := referenceLines[l]; !ok {
+				referenceLines[l] = lineNumber
+			}
+		}
+
+		if sb.writer.Err != nil {
+			return nil, &AssemblerError{lineNumber, sb.writer.Err}
+		}
+	}
+
+	for label, line := range referenceLines {
+		if _, ok := labelLines[label]; !ok {
+			return nil, &AssemblerError{line, fmt.Errorf("undefined label %s", label)}
+		}
+	}
+
+	script := sb.ToScript()
+	if script == nil {
+		script = []byte{}
+	}
+
+	return script, nil
+}
+
+// assembleInstruction emits a single instruction and returns labels it refers to
+func (s ScriptBuilder) assembleInstruction(text string) ([]string, error) {
+	mnemonic, operandsText := text, ""
+	if i := strings.IndexFunc(text, unicode.IsSpace); i >= 0 {
+		mnemonic, operandsText = text[:i], text[i+1:]
+	}
+	mnemonic = strings.TrimSpace(mnemonic)
+
+	opcode, err := vm.ParseOpcode(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	kinds, ok := opcode.Operands()
+	if !ok {
+		return nil, fmt.Errorf("opcode %s is not supported", opcode)
+	}
+
+	operands, err := splitOperands(operandsText)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(operands) != len(kinds) {
+		return nil, fmt.Errorf("%s expects %d operand(s), got %d", opcode, len(kinds), len(operands))
+	}
+
+	if opcode == vm.LOAD {
+		reg, err := parseRegister(operands[0])
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := ParseValue(operands[1])
+		if err != nil {
+			return nil, err
+		}
+
+		if len(value.Bytes) > 0xFFFF {
+			return nil, fmt.Errorf("literal is too big (%d bytes)", len(value.Bytes))
+		}
+
+		s.EmitLoad(reg, value.Bytes, value.Type)
+		return nil, nil
+	}
+
+	var labels []string
+
+	s.EmitS(opcode)
+	for i, kind := range kinds {
+		operand := operands[i]
+
+		switch kind {
+		case vm.RegisterOperand:
+			reg, err := parseRegister(operand)
+			if err != nil {
+				return nil, err
+			}
+			s.writer.WriteB(reg)
+		case vm.CountOperand:
+			n, err := strconv.ParseUint(operand, 0, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid count %s", operand)
+			}
+			s.writer.WriteB(byte(n))
+		case vm.TypeOperand:
+			t, err := vm.ParseVMType(operand)
+			if err != nil {
+				return nil, err
+			}
+			s.writer.WriteB(byte(t))
+		case vm.OffsetOperand:
+			if strings.HasPrefix(operand, "@") {
+				n, err := strconv.ParseUint(operand[1:], 0, 16)
+				if err != nil {
+					return nil, fmt.Errorf("invalid offset %s", operand)
+				}
+				s.writer.WriteU16LE(uint16(n))
+			} else {
+				if !isIdentifier(operand) {
+					return nil, fmt.Errorf("invalid label %s", operand)
+				}
+				s.emitJumpOffset(operand)
+				labels = append(labels, operand)
+			}
+		case vm.VarIntOperand:
+			n, err := strconv.ParseUint(operand, 0, 16)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %s", operand)
+			}
+			s.writer.WriteVarUint(n)
+		default:
+			return nil, fmt.Errorf("unexpected operand %s", operand)
+		}
+	}
+
+	return labels, nil
+}
+
+// ParseValue parses a LOAD literal, see Assemble() for supported syntax
+func ParseValue(literal string) (vm.Value, error) {
+	literal = strings.TrimSpace(literal)
+
+	switch {
+	case strings.HasPrefix(literal, `"`):
+		s, err := strconv.Unquote(literal)
+		if err != nil {
+			return vm.Value{}, fmt.Errorf("invalid string literal %s", literal)
+		}
+		return vm.Value{Type: vm.String, Bytes: []byte(s)}, nil
+
+	case literal == "true":
+		return vm.Value{Type: vm.Bool, Bytes: []byte{1}}, nil
+
+	case literal == "false":
+		return vm.Value{Type: vm.Bool, Bytes: []byte{0}}, nil
+
+	case strings.HasPrefix(literal, "0x"):
+		b, err := hex.DecodeString(literal[2:])
+		if err != nil {
+			return vm.Value{}, fmt.Errorf("invalid bytes literal %s", literal)
+		}
+		return vm.Value{Type: vm.Bytes, Bytes: b}, nil
+
+	case strings.HasPrefix(literal, "enum("), strings.HasPrefix(literal, "time("):
+		name, arg, err := parseCall(literal)
+		if err != nil {
+			return vm.Value{}, err
+		}
+		n, err := strconv.ParseUint(arg, 10, 32)
+		if err != nil {
+			return vm.Value{}, fmt.Errorf("invalid %s value %s", name, arg)
+		}
+		b := make([]byte, 4)
+		binary.LittleEndian.PutUint32(b, uint32(n))
+		if name == "enum" {
+			return vm.Value{Type: vm.Enum, Bytes: b}, nil
+		}
+		return vm.Value{Type: vm.Timestamp, Bytes: b}, nil
+
+	case strings.HasPrefix(literal, "raw("):
+		_, arg, err := parseCall(literal)
+		if err != nil {
+			return vm.Value{}, err
+		}
+		typeText, bytesText, ok := strings.Cut(arg, ",")
+		if !ok {
+			return vm.Value{}, fmt.Errorf("raw literal expects type and bytes: %s", literal)
+		}
+		t, err := vm.ParseVMType(strings.TrimSpace(typeText))
+		if err != nil {
+			return vm.Value{}, err
+		}
+		bytesText = strings.TrimSpace(bytesText)
+		if !strings.HasPrefix(bytesText, "0x") {
+			return vm.Value{}, fmt.Errorf("raw literal bytes must be in 0x format: %s", literal)
+		}
+		b, err := hex.DecodeString(bytesText[2:])
+		if err != nil {
+			return vm.Value{}, fmt.Errorf("invalid bytes literal %s", bytesText)
+		}
+		return vm.Value{Type: t, Bytes: b}, nil
+	}
+
+	n, ok := big.NewInt(0).SetString(literal, 10)
+	if !ok {
+		return vm.Value{}, fmt.Errorf("invalid literal %s", literal)
+	}
+
+	return vm.Value{Type: vm.Number, Bytes: vm.EncodeNumber(n)}, nil
+}
+
+func parseCall(text string) (string, string, error) {
+	name, arg, ok := strings.Cut(text, "(")
+	if !ok || !strings.HasSuffix(arg, ")") {
+		return "", "", fmt.Errorf("invalid literal %s", text)
+	}
+
+	return name, strings.TrimSpace(arg[:len(arg)-1]), nil
+}
+
+func parseRegister(text string) (byte, error) {
+	if len(text) < 2 || (text[0] != 'r' && text[0] != 'R') {
+		return 0, fmt.Errorf("invalid register %s", text)
+	}
+
+	n, err := strconv.ParseUint(text[1:], 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid register %s", text)
+	}
+
+	return byte(n), nil
+}
+
+// stripComment removes everything after ';' which is not a part of a string literal
+func stripComment(line string) string {
+	inString := false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			if inString {
+				i++
+			}
+		case '"':
+			inString = !inString
+		case ';':
+			if !inString {
+				return line[:i]
+			}
+		}
+	}
+
+	return line
+}
+
+func splitLabel(line string) (string, string, error) {
+	if strings.HasPrefix(line, `"`) {
+		return "", line, nil
+	}
+
+	label, rest, ok := strings.Cut(line, ":")
+	if !ok || strings.ContainsAny(label, ` "`) {
+		return "", line, nil
+	}
+
+	if !isIdentifier(label) {
+		return "", "", fmt.Errorf("invalid label %s", label)
+	}
+
+	return label, strings.TrimSpace(rest), nil
+}
+
+// splitOperands splits operands by commas which are not a part of string literals or parentheses
+func splitOperands(text string) ([]string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, nil
+	}
+
+	var result []string
+	inString := false
+	depth := 0
+	start := 0
+
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			if inString {
+				i++
+			}
+		case '"':
+			inString = !inString
+		case '(':
+			if !inString {
+				depth++
+			}
+		case ')':
+			if !inString {
+				depth--
+			}
+		case ',':
+			if !inString && depth == 0 {
+				result = append(result, strings.TrimSpace(text[start:i]))
+				start = i + 1
+			}
+		}
+	}
+
+	if inString {
+		return nil, errors.New("unterminated string literal")
+	}
+
+	result = append(result, strings.TrimSpace(text[start:]))
+	for _, r := range result {
+		if r == "" {
+			return nil, errors.New("empty operand")
+		}
+	}
+
+	return result, nil
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, c := range s {
+		switch {
+		case c == '_' || c == '.' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
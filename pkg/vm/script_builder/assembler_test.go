@@ -0,0 +1,136 @@
+package scriptbuilder_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssembleMatchesScriptBuilder(t *testing.T) {
+	expected := scriptbuilder.BeginScript().
+		CallContract("stake", "GetMasterCount").
+		EndScript()
+
+	script, err := scriptbuilder.Assemble(`
+		; stake.GetMasterCount()
+		LOAD r0, "GetMasterCount"
+		PUSH r0
+		LOAD r0, "stake"
+		CTX r0, r1
+		SWITCH r1
+		RET
+	`)
+
+	require.Nil(t, err)
+	assert.Equal(t, expected, script)
+}
+
+func TestAssembleLiterals(t *testing.T) {
+	script, err := scriptbuilder.Assemble(`
+		LOAD r0, "a;b, c" ; comment
+		LOAD r1, -129
+		LOAD r2, true
+		LOAD r3, 0x0a0b
+		LOAD r4, enum(3)
+		LOAD r5, time(1623519055)
+		LOAD r6, raw(Struct, 0x00)
+	`)
+	require.Nil(t, err)
+
+	instructions, err := vm.Disassemble(script)
+	require.Nil(t, err)
+	require.Len(t, instructions, 7)
+
+	assert.Equal(t, vm.Value{Type: vm.String, Bytes: []byte("a;b, c")}, instructions[0].Args[1])
+	assert.Equal(t, vm.Value{Type: vm.Number, Bytes: []byte{127, 255}}, instructions[1].Args[1])
+	assert.Equal(t, vm.Value{Type: vm.Bool, Bytes: []byte{1}}, instructions[2].Args[1])
+	assert.Equal(t, vm.Value{Type: vm.Bytes, Bytes: []byte{0x0a, 0x0b}}, instructions[3].Args[1])
+	assert.Equal(t, vm.Value{Type: vm.Enum, Bytes: []byte{3, 0, 0, 0}}, instructions[4].Args[1])
+	assert.Equal(t, vm.Value{Type: vm.Timestamp, Bytes: []byte{79, 239, 196, 96}}, instructions[5].Args[1])
+	assert.Equal(t, vm.Value{Type: vm.Struct, Bytes: []byte{0}}, instructions[6].Args[1])
+}
+
+func TestAssembleLabels(t *testing.T) {
+	script, err := scriptbuilder.Assemble(`
+		LOAD r0, 0
+		LOAD r1, 3
+	loop:
+		INC r0
+		LT r0, r1, r2
+		JMPIF r2, loop
+		JMP end
+		THROW r0
+	end: RET
+	`)
+	require.Nil(t, err)
+
+	instructions, err := vm.Disassemble(script)
+	require.Nil(t, err)
+
+	loop := instructions[2].Offset
+	end := instructions[len(instructions)-1].Offset
+	assert.Equal(t, vm.JMPIF, instructions[4].Opcode)
+	assert.Equal(t, uint16(loop), instructions[4].Args[1])
+	assert.Equal(t, vm.JMP, instructions[5].Opcode)
+	assert.Equal(t, uint16(end), instructions[5].Args[0])
+}
+
+func TestAssembleWhitespace(t *testing.T) {
+	script, err := scriptbuilder.Assemble("LOAD\tr0, 1\nPUSH\t r0\nRET")
+	require.Nil(t, err)
+
+	expected := scriptbuilder.BeginScript().EmitLoad(0, []byte{1}, vm.Number).EmitPush(0).EndScript()
+	assert.Equal(t, expected, script)
+}
+
+func TestAssembleRoundTrip(t *testing.T) {
+	from, _ := cryptography.FromString("P2KM9FjYrDXnPPAynLXAHdQ8wYz8de9VbDeybrLepnw6C5x")
+
+	script := scriptbuilder.BeginScript().
+		AllowGas(from, cryptography.NullAddress(), big.NewInt(100000), big.NewInt(21000)).
+		TransferTokens("SOUL", from, from, big.NewInt(-100000000)).
+		EmitLabel("again").
+		EmitJump(vm.JMPNOT, "again", 2).
+		EmitCall("again", 3).
+		EmitLoad(0, []byte{0x80}, vm.Number).
+		EmitLoad(0, []byte{0xff}, vm.String).
+		SpendGas(from).
+		EndScript()
+
+	text, err := vm.DisassembleToString(script)
+	require.Nil(t, err)
+
+	assembled, err := scriptbuilder.Assemble(text)
+	require.Nil(t, err, text)
+	assert.Equal(t, script, assembled)
+}
+
+func TestAssembleErrors(t *testing.T) {
+	cases := []struct {
+		source string
+		line   int
+	}{
+		{"NOP\nFOO r0", 2},
+		{"PUSH r0, r1", 1},
+		{"PUSH x0", 1},
+		{"\n\nLOAD r0, \"unterminated", 3},
+		{"LOAD r0, 12abc", 1},
+		{"JMP nowhere\nRET", 1},
+		{"a:\nNOP\na: RET", 3},
+		{"CAST r0, r1, Float", 1},
+	}
+
+	for _, c := range cases {
+		_, err := scriptbuilder.Assemble(c.source)
+
+		var asmErr *scriptbuilder.AssemblerError
+		require.True(t, errors.As(err, &asmErr), c.source)
+		assert.Equal(t, c.line, asmErr.Line, c.source)
+	}
+}
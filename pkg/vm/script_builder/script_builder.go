@@ -10,26 +10,56 @@ import (
 	"time"
 
 	crypto "github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/types"
 	"github.com/phantasma-io/phantasma-go/pkg/io"
+	"github.com/phantasma-io/phantasma-go/pkg/util"
 	"github.com/phantasma-io/phantasma-go/pkg/vm"
 )
 
 type ScriptBuilder struct {
 	writer *io.BufBinWriter
-}
 
-var _jumpLocations = make(map[int]string)
-var _labelLocations = make(map[string]int)
+	jumpLocations  map[int]string
+	labelLocations map[string]int
+}
 
 func BeginScript() ScriptBuilder {
-	sb := ScriptBuilder{writer: io.NewBufBinWriter()}
+	sb := ScriptBuilder{writer: io.NewBufBinWriter(),
+		jumpLocations:  make(map[int]string),
+		labelLocations: make(map[string]int)}
 	return sb
 }
 
 func (s ScriptBuilder) EndScript() []byte {
 	s.writer.WriteB(byte(vm.RET))
 
-	return s.writer.Bytes()
+	return s.ToScript()
+}
+
+// ToScript returns script built so far, without appending RET. Jump and call offsets are resolved using emitted labels.
+// Nil is returned if script can't be built, Err returns the reason.
+func (s ScriptBuilder) ToScript() []byte {
+	for _, label := range s.jumpLocations {
+		if _, ok := s.labelLocations[label]; !ok && s.writer.Err == nil {
+			s.writer.Err = fmt.Errorf("unknown label %s", label)
+		}
+	}
+
+	script := s.writer.Bytes()
+	if script == nil {
+		return nil
+	}
+
+	for ofs, label := range s.jumpLocations {
+		binary.LittleEndian.PutUint16(script[ofs:], uint16(s.labelLocations[label]))
+	}
+
+	return script
+}
+
+// Err returns the first error occurred while building script, it should be checked when ToScript or EndScript return nil
+func (s ScriptBuilder) Err() error {
+	return s.writer.Err
 }
 
 func (s ScriptBuilder) EmitS(opcode vm.Opcode) ScriptBuilder {
@@ -87,12 +117,21 @@ func (s ScriptBuilder) EmitLoadBool(reg byte, toLoad bool) ScriptBuilder {
 }
 
 func (s ScriptBuilder) EmitLoadTime(reg byte, toLoad time.Time) ScriptBuilder {
-	bytes := make([]byte, 8)
-	binary.LittleEndian.PutUint64(bytes, uint64(toLoad.Unix()))
+	// Timestamp is stored as uint32 number of seconds
+	bytes := make([]byte, 4)
+	binary.LittleEndian.PutUint32(bytes, uint32(toLoad.Unix()))
 	s.EmitLoad(reg, bytes, vm.Timestamp)
 	return s
 }
 
+// EmitLoadEnum loads enum value into register, it's stored as little-endian uint32
+func (s ScriptBuilder) EmitLoadEnum(reg byte, toLoad uint32) ScriptBuilder {
+	bytes := make([]byte, 4)
+	binary.LittleEndian.PutUint32(bytes, toLoad)
+	s.EmitLoad(reg, bytes, vm.Enum)
+	return s
+}
+
 func (s ScriptBuilder) EmitLoadInt(reg byte, toLoad int) ScriptBuilder {
 	str := strconv.Itoa(toLoad)
 	s.EmitLoadString(reg, str)
@@ -128,6 +167,58 @@ func (s ScriptBuilder) EmitLoad(reg byte, bytes []byte, _type vm.VMType) ScriptB
 	return s
 }
 
+// EmitLoadObject loads vm object into register keeping its type. Struct fields are loaded into the next two
+// registers and put into the struct one by one, so arrays and nested structs can be passed to methods.
+// Nil object is loaded as None.
+func (s ScriptBuilder) EmitLoadObject(reg byte, obj *vm.VMObject) ScriptBuilder {
+	if obj == nil {
+		obj = &vm.VMObject{}
+	}
+
+	switch obj.Type {
+	case vm.None:
+		s.EmitM(vm.CLEAR, []byte{reg})
+	case vm.Bool:
+		s.EmitLoadBool(reg, obj.Data.(bool))
+	case vm.Bytes:
+		s.EmitLoad(reg, obj.Data.([]byte), vm.Bytes)
+	case vm.Enum:
+		s.EmitLoadEnum(reg, obj.Data.(uint32))
+	case vm.Number:
+		n := obj.Data.(big.Int)
+		s.EmitLoad(reg, util.BigIntToPhantasmaByteArray(&n), vm.Number)
+	case vm.String:
+		s.EmitLoadString(reg, obj.Data.(string))
+	case vm.Timestamp:
+		bytes := make([]byte, 4)
+		binary.LittleEndian.PutUint32(bytes, obj.Data.(types.Timestamp).Value)
+		s.EmitLoad(reg, bytes, vm.Timestamp)
+	case vm.Object:
+		a, err := obj.AsAddress()
+		if err != nil {
+			if s.writer.Err == nil {
+				s.writer.Err = err
+			}
+			return s
+		}
+		s.EmitLoad(reg, a.BytesPrefixed(), vm.Bytes)
+	case vm.Struct:
+		s.EmitM(vm.CLEAR, []byte{reg})
+		fields := obj.Data.(*vm.VMStruct).Fields
+		for i := range fields {
+			s.EmitLoadObject(reg+1, &fields[i].Value)
+			s.EmitLoadObject(reg+2, &fields[i].Key)
+			s.EmitM(vm.PUT, []byte{reg + 1, reg, reg + 2})
+		}
+	default:
+		if s.writer.Err == nil {
+			s.writer.Err = fmt.Errorf("unsupported object type %s", obj.Type)
+		}
+	}
+
+	return s
+}
+
 func (s ScriptBuilder) EmitMove(srcReg byte, dstReg byte) ScriptBuilder {
 	s.EmitS(vm.MOVE)
 	s.writer.WriteB(srcReg)
@@ -144,31 +235,41 @@ func (s ScriptBuilder) EmitCopy(srcReg byte, dstReg byte) ScriptBuilder {
 
 func (s ScriptBuilder) EmitLabel(label string) ScriptBuilder {
 	s.EmitS(vm.NOP)
-	// TODO not sure if that works
-	_labelLocations[label] = s.writer.Len()
+	s.labelLocations[label] = s.writer.Len()
 	return s
 }
 
 func (s ScriptBuilder) EmitJump(opcode vm.Opcode, label string, reg byte) ScriptBuilder {
 
 	switch opcode {
-	case vm.JMP:
-	case vm.JMPIF:
-	case vm.JMPNOT:
+	case vm.JMP, vm.JMPIF, vm.JMPNOT:
 		s.EmitS(opcode)
 	default:
-		// TODO error
+		if s.writer.Err == nil {
+			s.writer.Err = fmt.Errorf("invalid jump opcode %s", opcode)
+		}
+		return s
 	}
 
 	if opcode != vm.JMP {
 		s.writer.WriteB(reg)
 	}
 
+	s.emitJumpOffset(label)
+
+	return s
+}
+
+// emitJumpOffset writes placeholder for a jump offset, which is resolved when script is finished
+func (s ScriptBuilder) emitJumpOffset(label string) {
 	ofs := s.writer.Len()
 	s.writer.WriteU16LE(0)
-	_jumpLocations[ofs] = label
+	s.jumpLocations[ofs] = label
+}
 
-	return s
+// markLabel binds label to the current position without emitting NOP
+func (s ScriptBuilder) markLabel(label string) {
+	s.labelLocations[label] = s.writer.Len()
 }
 
 func (s ScriptBuilder) EmitCall(label string, regCnt byte) ScriptBuilder {
@@ -179,14 +280,21 @@ func (s ScriptBuilder) EmitCall(label string, regCnt byte) ScriptBuilder {
 	s.writer.WriteB(regCnt)
 	s.writer.WriteU16LE(0)
 
-	_jumpLocations[ofs] = label
+	s.jumpLocations[ofs] = label
 
 	return s
 }
 
+// EmitConditionalJump emits JMPIF or JMPNOT to label depending on bool value of srcReg
 func (s ScriptBuilder) EmitConditionalJump(opcode vm.Opcode, srcReg byte, label string) ScriptBuilder {
-	panic("TODO!!!!!!!!!!!!!!!!")
-	//return s
+	if opcode != vm.JMPIF && opcode != vm.JMPNOT {
+		if s.writer.Err == nil {
+			s.writer.Err = fmt.Errorf("invalid conditional jump opcode %s", opcode)
+		}
+		return s
+	}
+
+	return s.EmitJump(opcode, label, srcReg)
 }
 
 func (s ScriptBuilder) EmitVarBytes(value int) ScriptBuilder {
@@ -215,6 +323,8 @@ func (s ScriptBuilder) loadIntoReg(dstReg byte, arg interface{}) {
 		s.EmitLoadTime(dstReg, arg.(time.Time))
 	case crypto.Address:
 		s.EmitLoad(dstReg, arg.(crypto.Address).BytesPrefixed(), vm.Bytes)
+	case *vm.VMObject:
+		s.EmitLoadObject(dstReg, e)
 	//TODO array
 	default:
 		if arg != nil {
@@ -0,0 +1,144 @@
+package scriptbuilder
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	crypto "github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/contract"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+	orderedmap "github.com/wk8/go-ordered-map/v2"
+)
+
+var (
+	// ErrMethodNotFound is returned when called method is not present in contract ABI
+	ErrMethodNotFound = errors.New("method not found in contract ABI")
+	// ErrArgumentCount is returned when number of arguments doesn't match ABI
+	ErrArgumentCount = errors.New("wrong number of arguments")
+	// ErrArgumentType is returned when argument can't be passed as a parameter of ABI type
+	ErrArgumentType = errors.New("argument type mismatch")
+)
+
+// ContractInterfaceFromResult converts contract ABI returned by getContract into contract.ContractInterface
+func ContractInterfaceFromResult(c resp.ContractResult) (*contract.ContractInterface, error) {
+	abi := &contract.ContractInterface{Methods: orderedmap.New[string, contract.ContractMethod]()}
+
+	for _, m := range c.Methods {
+		returnType, err := vm.ParseVMType(m.ReturnType)
+		if err != nil {
+			return nil, fmt.Errorf("method %s return type: %w", m.Name, err)
+		}
+
+		method := contract.ContractMethod{Name: m.Name, ReturnType: returnType}
+		for _, p := range m.Parameters {
+			t, err := vm.ParseVMType(p.Type)
+			if err != nil {
+				return nil, fmt.Errorf("method %s parameter %s: %w", m.Name, p.Name, err)
+			}
+			method.Parameters = append(method.Parameters, contract.ContractParameter{Name: p.Name, Type: t})
+		}
+		abi.Methods.Set(m.Name, method)
+	}
+
+	for _, e := range c.Events {
+		returnType, err := vm.ParseVMType(e.ReturnType)
+		if err != nil {
+			return nil, fmt.Errorf("event %s return type: %w", e.Name, err)
+		}
+		abi.Events = append(abi.Events, contract.ContractEvent{
+			Value:       byte(e.Value),
+			Name:        e.Name,
+			ReturnType:  returnType,
+			Description: []byte(e.Description),
+		})
+	}
+
+	return abi, nil
+}
+
+// ValidateCall checks that method exists in contract ABI and args can be passed as its parameters
+func ValidateCall(abi *contract.ContractInterface, method string, args ...interface{}) error {
+	if abi == nil || abi.Methods == nil {
+		return fmt.Errorf("%s: %w", method, ErrMethodNotFound)
+	}
+
+	m, ok := abi.Methods.Get(method)
+	if !ok {
+		return fmt.Errorf("%s: %w", method, ErrMethodNotFound)
+	}
+
+	if len(args) != len(m.Parameters) {
+		return fmt.Errorf("%s: %w, expected %d, got %d", method, ErrArgumentCount, len(m.Parameters), len(args))
+	}
+
+	for i, p := range m.Parameters {
+		if !isCompatible(p.Type, args[i]) {
+			return fmt.Errorf("%s: %w, argument %d (%s) expects %s, got %T", method, ErrArgumentType, i, p.Name, p.Type, args[i])
+		}
+	}
+
+	return nil
+}
+
+// isCompatible checks if argument, loaded by loadIntoReg, will be accepted by the node as a parameter of given type.
+// Vm objects are loaded as is, so their type should match. Arrays are structs with Number keys.
+// Enum values can only be passed as vm objects, since ints are loaded as numbers.
+func isCompatible(t vm.VMType, arg interface{}) bool {
+	if obj, ok := arg.(*vm.VMObject); ok {
+		return obj != nil && obj.Type == t
+	}
+
+	switch t {
+	case vm.Number:
+		switch a := arg.(type) {
+		case int:
+			return true
+		case *big.Int:
+			return a != nil
+		}
+	case vm.String:
+		_, ok := arg.(string)
+		return ok
+	case vm.Bool:
+		_, ok := arg.(bool)
+		return ok
+	case vm.Bytes:
+		_, ok := arg.([]byte)
+		return ok
+	case vm.Timestamp:
+		_, ok := arg.(time.Time)
+		return ok
+	case vm.Object:
+		switch a := arg.(type) {
+		case crypto.Address:
+			return true
+		case []byte:
+			return len(a) == crypto.Length || (len(a) == crypto.Length+1 && a[0] == crypto.Length)
+		}
+	}
+
+	return false
+}
+
+// CallContractABI validates the call against contract ABI and appends it to the script.
+// Script is not modified if validation fails.
+func (s ScriptBuilder) CallContractABI(abi *contract.ContractInterface, contractName, method string, args ...interface{}) (ScriptBuilder, error) {
+	if err := ValidateCall(abi, method, args...); err != nil {
+		return s, fmt.Errorf("%s.%w", contractName, err)
+	}
+
+	return s.CallContract(contractName, method, args...), nil
+}
+
+// CallContractResult is like CallContractABI, but takes contract ABI in the format returned by getContract
+func (s ScriptBuilder) CallContractResult(c resp.ContractResult, method string, args ...interface{}) (ScriptBuilder, error) {
+	abi, err := ContractInterfaceFromResult(c)
+	if err != nil {
+		return s, fmt.Errorf("invalid %s contract ABI: %w", c.Name, err)
+	}
+
+	return s.CallContractABI(abi, c.Name, method, args...)
+}
@@ -0,0 +1,122 @@
+package scriptbuilder_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	resp "github.com/phantasma-io/phantasma-go/pkg/rpc/response"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var stakeABI = resp.ContractResult{
+	Name: "stake",
+	Methods: []resp.ABIMethodResult{
+		{Name: "Stake", ReturnType: "None", Parameters: []resp.ABIParameterResult{
+			{Name: "from", Type: "Object"}, {Name: "stakeAmount", Type: "Number"},
+		}},
+		{Name: "GetStake", ReturnType: "Number", Parameters: []resp.ABIParameterResult{
+			{Name: "address", Type: "Object"},
+		}},
+	},
+}
+
+var consensusABI = resp.ContractResult{
+	Name: "consensus",
+	Methods: []resp.ABIMethodResult{
+		{Name: "MultiVote", ReturnType: "None", Parameters: []resp.ABIParameterResult{
+			{Name: "from", Type: "Object"}, {Name: "subject", Type: "String"}, {Name: "choices", Type: "Struct"},
+		}},
+	},
+}
+
+func TestCallContractResultStruct(t *testing.T) {
+	address, _ := cryptography.FromString("P2KM9FjYrDXnPPAynLXAHdQ8wYz8de9VbDeybrLepnw6C5x")
+	vote := vm.NewVMStruct()
+	vote.Set(*vm.NewString("index"), *vm.NewNumber(big.NewInt(0)))
+	vote.Set(*vm.NewString("percentage"), *vm.NewNumber(big.NewInt(100)))
+	choices := vm.NewVMStruct()
+	choices.Set(*vm.NewNumber(big.NewInt(0)), vm.VMObject{Type: vm.Struct, Data: vote})
+	votes := &vm.VMObject{Type: vm.Struct, Data: choices}
+
+	sb, err := scriptbuilder.BeginScript().CallContractResult(consensusABI, "MultiVote", address, "elections", votes)
+	require.Nil(t, err)
+	expected := scriptbuilder.BeginScript().CallContract("consensus", "MultiVote", address, "elections", votes).EndScript()
+	assert.Equal(t, expected, sb.EndScript())
+
+	_, err = scriptbuilder.BeginScript().CallContractResult(consensusABI, "MultiVote", address, vm.NewString("elections"), votes)
+	assert.Nil(t, err)
+
+	_, err = scriptbuilder.BeginScript().CallContractResult(consensusABI, "MultiVote", address, "elections", vm.NewString("0"))
+	assert.True(t, errors.Is(err, scriptbuilder.ErrArgumentType))
+
+	_, err = scriptbuilder.BeginScript().CallContractResult(consensusABI, "MultiVote", address, "elections", (*vm.VMObject)(nil))
+	assert.True(t, errors.Is(err, scriptbuilder.ErrArgumentType))
+}
+
+func TestCallContractResult(t *testing.T) {
+	address, _ := cryptography.FromString("P2KM9FjYrDXnPPAynLXAHdQ8wYz8de9VbDeybrLepnw6C5x")
+
+	sb, err := scriptbuilder.BeginScript().CallContractResult(stakeABI, "Stake", address, big.NewInt(100))
+	require.Nil(t, err)
+	expected := scriptbuilder.BeginScript().CallContract("stake", "Stake", address, big.NewInt(100)).EndScript()
+	assert.Equal(t, expected, sb.EndScript())
+
+	_, err = scriptbuilder.BeginScript().CallContractResult(stakeABI, "Stake", address.BytesPrefixed(), 100)
+	assert.Nil(t, err)
+
+	// strings are loaded as String, not as Object or Number
+	_, err = scriptbuilder.BeginScript().CallContractResult(stakeABI, "Stake", address.String(), big.NewInt(100))
+	assert.True(t, errors.Is(err, scriptbuilder.ErrArgumentType))
+
+	_, err = scriptbuilder.BeginScript().CallContractResult(stakeABI, "Stake", address, "100")
+	assert.True(t, errors.Is(err, scriptbuilder.ErrArgumentType))
+
+	_, err = scriptbuilder.BeginScript().CallContractResult(stakeABI, "Unstake", address, big.NewInt(100))
+	assert.True(t, errors.Is(err, scriptbuilder.ErrMethodNotFound))
+
+	_, err = scriptbuilder.BeginScript().CallContractResult(stakeABI, "Stake", address)
+	assert.True(t, errors.Is(err, scriptbuilder.ErrArgumentCount))
+
+	_, err = scriptbuilder.BeginScript().CallContractResult(stakeABI, "Stake", address, "1.5")
+	assert.True(t, errors.Is(err, scriptbuilder.ErrArgumentType))
+	assert.Equal(t, "stake.Stake: argument type mismatch, argument 1 (stakeAmount) expects Number, got string", err.Error())
+
+	_, err = scriptbuilder.BeginScript().CallContractResult(stakeABI, "GetStake", "not an address")
+	assert.True(t, errors.Is(err, scriptbuilder.ErrArgumentType))
+
+	_, err = scriptbuilder.BeginScript().CallContractResult(stakeABI, "GetStake", []string{"SOUL"})
+	assert.True(t, errors.Is(err, scriptbuilder.ErrArgumentType))
+}
+
+func TestCallContractResultEnum(t *testing.T) {
+	abi := resp.ContractResult{
+		Name: "account",
+		Methods: []resp.ABIMethodResult{
+			{Name: "SetKind", ReturnType: "None", Parameters: []resp.ABIParameterResult{{Name: "kind", Type: "Enum"}}},
+		},
+	}
+
+	_, err := scriptbuilder.BeginScript().CallContractResult(abi, "SetKind", vm.NewEnum(2))
+	assert.Nil(t, err)
+
+	// ints are loaded as Number
+	_, err = scriptbuilder.BeginScript().CallContractResult(abi, "SetKind", 2)
+	assert.True(t, errors.Is(err, scriptbuilder.ErrArgumentType))
+}
+
+func TestCallContractABIKeepsScript(t *testing.T) {
+	abi, err := scriptbuilder.ContractInterfaceFromResult(stakeABI)
+	require.Nil(t, err)
+
+	sb := scriptbuilder.BeginScript().EmitLoadString(0, "test")
+	sb, err = sb.CallContractABI(abi, "stake", "GetStake", 1)
+	assert.NotNil(t, err)
+
+	expected := scriptbuilder.BeginScript().EmitLoadString(0, "test").EndScript()
+	assert.Equal(t, expected, sb.EndScript())
+}
@@ -26,6 +26,16 @@ func (s ScriptBuilder) Unstake(address cryptography.Address, amount *big.Int) Sc
 	return s.CallContract("stake", "Unstake", address, amount)
 }
 
+// Claim claims KCAL generated by SOUL staked by stakeAddress to from
+func (s ScriptBuilder) Claim(from, stakeAddress cryptography.Address) ScriptBuilder {
+	return s.CallContract("stake", "Claim", from, stakeAddress)
+}
+
+// Compound claims KCAL generated by stake of address and stakes amount of SOUL more
+func (s ScriptBuilder) Compound(address cryptography.Address, amount *big.Int) ScriptBuilder {
+	return s.Claim(address, address).Stake(address, amount)
+}
+
 func (s ScriptBuilder) TransferTokens(symbol string, from, to cryptography.Address, amount *big.Int) ScriptBuilder {
 	return s.CallInterop("Runtime.TransferTokens", from, to, symbol, amount)
 }
@@ -33,3 +43,59 @@ func (s ScriptBuilder) TransferTokens(symbol string, from, to cryptography.Addre
 func (s ScriptBuilder) TransferBalance(symbol string, from, to cryptography.Address) ScriptBuilder {
 	return s.CallInterop("Runtime.TransferTokens", from, to, symbol)
 }
+
+// MintToken mints NFT of series seriesID with rom and ram to the address to, from should be allowed to mint the token
+func (s ScriptBuilder) MintToken(symbol string, from, to cryptography.Address, rom, ram []byte, seriesID *big.Int) ScriptBuilder {
+	return s.CallInterop("Runtime.MintToken", from, to, symbol, rom, ram, seriesID)
+}
+
+// TransferToken transfers NFT with tokenID from its owner from to the address to
+func (s ScriptBuilder) TransferToken(symbol string, from, to cryptography.Address, tokenID *big.Int) ScriptBuilder {
+	return s.CallInterop("Runtime.TransferToken", from, to, symbol, tokenID)
+}
+
+// BurnToken destroys NFT with tokenID owned by from
+func (s ScriptBuilder) BurnToken(symbol string, from cryptography.Address, tokenID *big.Int) ScriptBuilder {
+	return s.CallInterop("Runtime.BurnToken", from, symbol, tokenID)
+}
+
+// WriteToken replaces RAM of NFT with tokenID, ROM can't be changed
+func (s ScriptBuilder) WriteToken(symbol string, from cryptography.Address, tokenID *big.Int, ram []byte) ScriptBuilder {
+	return s.CallInterop("Runtime.WriteToken", from, symbol, tokenID, ram)
+}
+
+// InfuseToken infuses value of infuseSymbol into NFT, for non-fungible infuseSymbol value is the infused token ID
+func (s ScriptBuilder) InfuseToken(symbol string, from cryptography.Address, tokenID *big.Int, infuseSymbol string, value *big.Int) ScriptBuilder {
+	return s.CallInterop("Runtime.InfuseToken", from, symbol, tokenID, infuseSymbol, value)
+}
+
+// AddMember adds target to the organization, from should be a member allowed to manage it
+func (s ScriptBuilder) AddMember(organization string, from, target cryptography.Address) ScriptBuilder {
+	return s.CallInterop("Runtime.AddMember", organization, from, target)
+}
+
+// RemoveMember removes target from the organization, an address can remove itself
+func (s ScriptBuilder) RemoveMember(organization string, from, target cryptography.Address) ScriptBuilder {
+	return s.CallInterop("Runtime.RemoveMember", organization, from, target)
+}
+
+// CreateLeaderboard creates leaderboard keeping capacity best scores
+func (s ScriptBuilder) CreateLeaderboard(from cryptography.Address, name string, capacity *big.Int) ScriptBuilder {
+	return s.CallContract("ranking", "CreateLeaderboard", from, name, capacity)
+}
+
+// InsertScore inserts score of target into leaderboard, from should be the owner of leaderboard
+func (s ScriptBuilder) InsertScore(from, target cryptography.Address, name string, score *big.Int) ScriptBuilder {
+	return s.CallContract("ranking", "InsertScore", from, target, name, score)
+}
+
+// ResetLeaderboard removes all rows of leaderboard
+func (s ScriptBuilder) ResetLeaderboard(from cryptography.Address, name string) ScriptBuilder {
+	return s.CallContract("ranking", "ResetLeaderboard", from, name)
+}
+
+// CreateFile creates archive of fileSize bytes owned by target, contentMerkle is the serialized merkle tree of
+// archive blocks and encryption is the serialized archive encryption, it's empty for unencrypted archives
+func (s ScriptBuilder) CreateFile(target cryptography.Address, fileName string, fileSize *big.Int, contentMerkle, encryption []byte) ScriptBuilder {
+	return s.CallContract("storage", "CreateFile", target, fileName, fileSize, contentMerkle, encryption)
+}
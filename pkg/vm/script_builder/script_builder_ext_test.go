@@ -0,0 +1,186 @@
+package scriptbuilder_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// interopArgs executes script and returns arguments of the interop call, first argument first
+func interopArgs(t *testing.T, method string, script []byte) []vm.VMObject {
+	var args []vm.VMObject
+
+	machine := vm.NewVirtualMachine(script)
+	machine.RegisterInterop(method, func(m *vm.VirtualMachine) error {
+		for len(m.Stack()) > 0 {
+			obj, err := m.Pop()
+			if err != nil {
+				return err
+			}
+			args = append(args, *obj)
+		}
+		return nil
+	})
+
+	state, err := machine.Execute()
+	require.Nil(t, err)
+	require.Equal(t, vm.Halt, state)
+	return args
+}
+
+// contractArgs executes script and returns arguments of the contract method call, first argument first
+func contractArgs(t *testing.T, contract, method string, script []byte) []vm.VMObject {
+	var args []vm.VMObject
+
+	machine := vm.NewVirtualMachine(script)
+	machine.RegisterContext(vm.NewNativeContext(contract, map[string]vm.InteropHandler{
+		method: func(m *vm.VirtualMachine) error {
+			for len(m.Stack()) > 0 {
+				obj, err := m.Pop()
+				if err != nil {
+					return err
+				}
+				args = append(args, *obj)
+			}
+			return nil
+		},
+	}))
+
+	state, err := machine.Execute()
+	require.Nil(t, err)
+	require.Equal(t, vm.Halt, state)
+	return args
+}
+
+func TestNFTOperations(t *testing.T) {
+	from := cryptography.NewPhantasmaKeys(make([]byte, 32)).Address()
+	to := cryptography.NewPhantasmaKeys(append(make([]byte, 31), 1)).Address()
+	tokenID := big.NewInt(123456789)
+
+	args := interopArgs(t, "Runtime.MintToken", scriptbuilder.BeginScript().
+		MintToken("CROWN", from, to, []byte{1, 2}, []byte{3}, big.NewInt(2)).
+		EndScript())
+	require.Len(t, args, 6)
+	assert.Equal(t, from.String(), addressArg(t, args[0]))
+	assert.Equal(t, to.String(), addressArg(t, args[1]))
+	assert.Equal(t, "CROWN", args[2].AsString())
+	assert.Equal(t, []byte{1, 2}, bytesArg(t, args[3]))
+	assert.Equal(t, []byte{3}, bytesArg(t, args[4]))
+	assert.Equal(t, big.NewInt(2), args[5].AsNumber())
+
+	args = interopArgs(t, "Runtime.TransferToken", scriptbuilder.BeginScript().
+		TransferToken("CROWN", from, to, tokenID).
+		EndScript())
+	require.Len(t, args, 4)
+	assert.Equal(t, to.String(), addressArg(t, args[1]))
+	assert.Equal(t, tokenID, args[3].AsNumber())
+
+	args = interopArgs(t, "Runtime.BurnToken", scriptbuilder.BeginScript().
+		BurnToken("CROWN", from, tokenID).
+		EndScript())
+	require.Len(t, args, 3)
+	assert.Equal(t, "CROWN", args[1].AsString())
+	assert.Equal(t, tokenID, args[2].AsNumber())
+
+	args = interopArgs(t, "Runtime.WriteToken", scriptbuilder.BeginScript().
+		WriteToken("CROWN", from, tokenID, []byte{7}).
+		EndScript())
+	require.Len(t, args, 4)
+	assert.Equal(t, tokenID, args[2].AsNumber())
+	assert.Equal(t, []byte{7}, bytesArg(t, args[3]))
+
+	args = interopArgs(t, "Runtime.InfuseToken", scriptbuilder.BeginScript().
+		InfuseToken("CROWN", from, tokenID, "SOUL", big.NewInt(5_00000000)).
+		EndScript())
+	require.Len(t, args, 5)
+	assert.Equal(t, "CROWN", args[1].AsString())
+	assert.Equal(t, tokenID, args[2].AsNumber())
+	assert.Equal(t, "SOUL", args[3].AsString())
+	assert.Equal(t, big.NewInt(5_00000000), args[4].AsNumber())
+}
+
+func addressArg(t *testing.T, obj vm.VMObject) string {
+	a, err := obj.AsAddress()
+	require.Nil(t, err)
+	return a.String()
+}
+
+func bytesArg(t *testing.T, obj vm.VMObject) []byte {
+	b, err := obj.AsBytes()
+	require.Nil(t, err)
+	return b
+}
+
+func TestClaim(t *testing.T) {
+	from := cryptography.NewPhantasmaKeys(make([]byte, 32)).Address()
+
+	args := contractArgs(t, "stake", "Claim", scriptbuilder.BeginScript().
+		Claim(from, from).
+		EndScript())
+	require.Len(t, args, 2)
+	assert.Equal(t, from.String(), addressArg(t, args[0]))
+	assert.Equal(t, from.String(), addressArg(t, args[1]))
+}
+
+func TestMembers(t *testing.T) {
+	from := cryptography.NewPhantasmaKeys(make([]byte, 32)).Address()
+	target := cryptography.NullAddress()
+
+	args := interopArgs(t, "Runtime.AddMember", scriptbuilder.BeginScript().
+		AddMember("guild", from, target).
+		EndScript())
+	require.Len(t, args, 3)
+	assert.Equal(t, "guild", args[0].AsString())
+	assert.Equal(t, from.String(), addressArg(t, args[1]))
+	assert.Equal(t, target.String(), addressArg(t, args[2]))
+
+	args = interopArgs(t, "Runtime.RemoveMember", scriptbuilder.BeginScript().
+		RemoveMember("guild", from, from).
+		EndScript())
+	require.Len(t, args, 3)
+	assert.Equal(t, from.String(), addressArg(t, args[2]))
+}
+
+func TestLeaderboard(t *testing.T) {
+	from := cryptography.NewPhantasmaKeys(make([]byte, 32)).Address()
+	target := cryptography.NullAddress()
+
+	args := contractArgs(t, "ranking", "CreateLeaderboard", scriptbuilder.BeginScript().
+		CreateLeaderboard(from, "season1", big.NewInt(100)).
+		EndScript())
+	require.Len(t, args, 3)
+	assert.Equal(t, from.String(), addressArg(t, args[0]))
+	assert.Equal(t, "season1", args[1].AsString())
+	assert.Equal(t, big.NewInt(100), args[2].AsNumber())
+
+	args = contractArgs(t, "ranking", "InsertScore", scriptbuilder.BeginScript().
+		InsertScore(from, target, "season1", big.NewInt(1500)).
+		EndScript())
+	require.Len(t, args, 4)
+	assert.Equal(t, target.String(), addressArg(t, args[1]))
+	assert.Equal(t, big.NewInt(1500), args[3].AsNumber())
+
+	args = contractArgs(t, "ranking", "ResetLeaderboard", scriptbuilder.BeginScript().
+		ResetLeaderboard(from, "season1").
+		EndScript())
+	require.Len(t, args, 2)
+}
+
+func TestCreateFile(t *testing.T) {
+	target := cryptography.NewPhantasmaKeys(make([]byte, 32)).Address()
+
+	args := contractArgs(t, "storage", "CreateFile", scriptbuilder.BeginScript().
+		CreateFile(target, "notes.txt", big.NewInt(2048), []byte{1, 2, 3}, []byte{}).
+		EndScript())
+	require.Len(t, args, 5)
+	assert.Equal(t, target.String(), addressArg(t, args[0]))
+	assert.Equal(t, "notes.txt", args[1].AsString())
+	assert.Equal(t, big.NewInt(2048), args[2].AsNumber())
+	assert.Equal(t, []byte{1, 2, 3}, bytesArg(t, args[3]))
+	assert.Empty(t, bytesArg(t, args[4]))
+}
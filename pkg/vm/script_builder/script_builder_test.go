@@ -3,10 +3,14 @@ package scriptbuilder_test
 import (
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/types"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
 	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewScript(t *testing.T) {
@@ -33,3 +37,70 @@ func TestNewScript(t *testing.T) {
 		sb.CallInterop("Runtime.TransferToken", fromAddress, toAddress, symbols, "TOKEN_ID")
 	})
 }
+
+func TestJumpErrors(t *testing.T) {
+	sb := scriptbuilder.BeginScript().EmitJump(vm.RET, "end", 0).EmitLabel("end")
+	assert.Nil(t, sb.EndScript())
+	assert.ErrorContains(t, sb.Err(), "invalid jump opcode")
+
+	sb = scriptbuilder.BeginScript().EmitJump(vm.JMP, "nowhere", 0)
+	assert.Nil(t, sb.EndScript())
+	assert.ErrorContains(t, sb.Err(), "unknown label nowhere")
+
+	sb = scriptbuilder.BeginScript().EmitLabel("start").EmitJump(vm.JMP, "start", 0)
+	assert.NotNil(t, sb.EndScript())
+}
+
+func TestEmitConditionalJump(t *testing.T) {
+	script := scriptbuilder.BeginScript().
+		EmitConditionalJump(vm.JMPNOT, 1, "end").
+		EmitLabel("end").
+		EndScript()
+	expected := scriptbuilder.BeginScript().
+		EmitJump(vm.JMPNOT, "end", 1).
+		EmitLabel("end").
+		EndScript()
+	assert.Equal(t, expected, script)
+
+	sb := scriptbuilder.BeginScript().EmitConditionalJump(vm.JMP, 1, "end").EmitLabel("end")
+	assert.Nil(t, sb.EndScript())
+	assert.ErrorContains(t, sb.Err(), "invalid conditional jump opcode")
+}
+
+func TestEmitLoadTime(t *testing.T) {
+	script := scriptbuilder.BeginScript().
+		EmitLoadTime(0, time.Unix(1623519055, 0)).
+		EmitPush(0).
+		EndScript()
+
+	machine := vm.NewVirtualMachine(script)
+	state, err := machine.Execute()
+	require.Nil(t, err)
+	assert.Equal(t, vm.Halt, state)
+
+	stack := machine.Stack()
+	require.Len(t, stack, 1)
+	assert.Equal(t, types.Timestamp{Value: 1623519055}, stack[0].Data)
+}
+
+func TestEmitLoadObject(t *testing.T) {
+	inner := vm.NewVMStruct()
+	inner.Set(*vm.NewNumber(big.NewInt(0)), *vm.NewEnum(7))
+	fields := vm.NewVMStruct()
+	fields.Set(*vm.NewString("name"), *vm.NewString("test"))
+	fields.Set(*vm.NewString("items"), vm.VMObject{Type: vm.Struct, Data: inner})
+	obj := &vm.VMObject{Type: vm.Struct, Data: fields}
+
+	script := scriptbuilder.BeginScript().EmitLoadObject(0, obj).EmitPush(0).EndScript()
+	machine := vm.NewVirtualMachine(script)
+	_, err := machine.Execute()
+	require.Nil(t, err)
+
+	stack := machine.Stack()
+	require.Len(t, stack, 1)
+	assert.Equal(t, obj, &stack[0])
+
+	sb := scriptbuilder.BeginScript().EmitLoadObject(0, &vm.VMObject{Type: vm.Object, Data: 42})
+	assert.Nil(t, sb.EndScript())
+	assert.ErrorContains(t, sb.Err(), "cannot convert")
+}
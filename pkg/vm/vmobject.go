@@ -1,10 +1,14 @@
 package vm
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/hex"
+	"fmt"
+	"math"
 	"math/big"
 	"strconv"
+	"strings"
 
 	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
 	"github.com/phantasma-io/phantasma-go/pkg/domain/types"
@@ -17,78 +21,95 @@ type VMObject struct {
 	Data interface{}
 }
 
-// AsNumber() returns value stored in vm.VMObject structure, in .Data field, as a *big.Int number
+// AsNumber() returns value stored in vm.VMObject structure, in .Data field, as a *big.Int number.
+// It panics if value can't be converted, use AsNumberE() to get an error instead.
 func (v *VMObject) AsNumber() *big.Int {
-	switch v.Type {
-	case None:
-		return big.NewInt(0)
+	n, err := v.AsNumberE()
+	if err != nil {
+		panic(err.Error())
+	}
 
-	case String:
-		b := big.NewInt(0)
-		b.SetString(v.Data.(string), 10)
-		return b
+	return n
+}
 
-	case Bytes:
-		// b := v.Data.([]byte)
-		// big.NewInt(0).SetBytes(b)
-		// TODO probably we need to invert byte order here, not sure if anyone is using this,
-		// leaving as unsupported for now
-		panic("Not supported!")
+// AsString() returns value stored in vm.VMObject structure, in .Data field, as a string.
+// It panics if value can't be converted, use AsStringE() to get an error instead.
+func (v *VMObject) AsString() string {
+	s, err := v.AsStringE()
+	if err != nil {
+		panic(err.Error())
+	}
 
-	case Enum:
-		return big.NewInt(int64(v.Data.(uint32)))
+	return s
+}
 
-	case Bool:
-		var val = v.Data.(bool)
-		if val {
-			return big.NewInt(1)
-		} else {
-			return big.NewInt(0)
-		}
+// AsNumberE() returns value as a *big.Int number. Bytes are treated as a little-endian
+// two's complement number, same way Phantasma node does.
+func (v *VMObject) AsNumberE() (*big.Int, error) {
+	return v.toNumber()
+}
 
-	case Number:
-		n := v.Data.(big.Int)
-		return &n
+// AsStringE() returns value as a string
+func (v *VMObject) AsStringE() (string, error) {
+	return v.toString()
+}
 
-	case Timestamp:
-		return big.NewInt(int64(v.Data.(types.Timestamp).Value))
+// AsBool() returns value as a bool
+func (v *VMObject) AsBool() (bool, error) {
+	return v.toBool()
+}
 
-	default:
-		panic("Unsupported type")
-	}
+// AsBytes() returns value as a byte array
+func (v *VMObject) AsBytes() ([]byte, error) {
+	return v.toBytes()
 }
 
-// AsString() returns value stored in vm.VMObject structure, in .Data field, as a string
-func (v *VMObject) AsString() string {
+// AsTimestamp() returns value as a timestamp
+func (v *VMObject) AsTimestamp() (types.Timestamp, error) {
 	switch v.Type {
+	case Timestamp:
+		return v.Data.(types.Timestamp), nil
+	case Number, Enum:
+		n, _ := v.toNumber()
+		if !n.IsUint64() || n.Uint64() > math.MaxUint32 {
+			return types.Timestamp{}, fmt.Errorf("value %s is out of timestamp range", n)
+		}
+		return types.Timestamp{Value: uint32(n.Uint64())}, nil
+	}
 
-	case String:
-		return v.Data.(string)
-
-	case Bytes:
-		return string(v.Data.([]byte))
-
-	case Enum:
-		return strconv.FormatInt(int64(v.Data.(uint32)), 10)
+	return types.Timestamp{}, fmt.Errorf("cannot convert %s to timestamp", v.Type)
+}
 
-	case Bool:
-		var val = v.Data.(bool)
-		if val {
-			return "true"
-		} else {
-			return "false"
+// AsArray() returns values of a struct with Number keys 0..n-1
+func (v *VMObject) AsArray() ([]VMObject, error) {
+	items, ok := arrayItems(v)
+	if !ok {
+		if v.Type == Struct {
+			return nil, fmt.Errorf("struct is not an array")
 		}
+		return nil, fmt.Errorf("cannot convert %s to array", v.Type)
+	}
 
-	case Number:
-		n := v.Data.(big.Int)
-		return n.String()
+	return items, nil
+}
 
-	case Timestamp:
-		return strconv.FormatInt(int64(v.Data.(types.Timestamp).Value), 10)
+// AsMap() returns fields of a struct, keys are converted to strings
+func (v *VMObject) AsMap() (map[string]VMObject, error) {
+	if v.Type != Struct {
+		return nil, fmt.Errorf("cannot convert %s to map", v.Type)
+	}
 
-	default:
-		panic("Unsupported type")
+	fields := v.Data.(*VMStruct).Fields
+	result := make(map[string]VMObject, len(fields))
+	for i := range fields {
+		key, err := fields[i].Key.toString()
+		if err != nil {
+			return nil, err
+		}
+		result[key] = fields[i].Value
 	}
+
+	return result, nil
 }
 
 func (v *VMObject) String() string {
@@ -123,66 +144,59 @@ func (v *VMObject) String() string {
 }
 
 func (v *VMObject) SetValue(val []byte, vmtype VMType) *VMObject {
-	v.Type = vmtype
-	// if val != nil {
-	// 	v._localSize = len(val)
-	// }
+	if err := v.SetValueE(val, vmtype); err != nil {
+		panic(err.Error())
+	}
+
+	return v
+}
 
+// SetValueE decodes value of the given type from bytes, same as SetValue, but returns an error instead of panicking
+func (v *VMObject) SetValueE(val []byte, vmtype VMType) error {
 	switch vmtype {
 	case Bytes:
-		{
-			v.Data = val
-			break
-		}
+		v.Data = val
 
 	case Number:
-		{
-			var n *big.Int
-			if val == nil || len(val) == 0 {
-				n = big.NewInt(0)
-			} else {
-				n = util.BigIntFromCsharpOrPhantasmaByteArray(val)
-			}
-
-			v.Data = *n
-			break
+		var n *big.Int
+		if len(val) == 0 {
+			n = big.NewInt(0)
+		} else {
+			n = util.BigIntFromCsharpOrPhantasmaByteArray(val)
 		}
 
+		v.Data = *n
+
 	case String:
-		{
-			v.Data = string(val)
-			break
-		}
+		v.Data = string(val)
 
 	case Enum:
-		{
-			v.Data = binary.BigEndian.Uint32(val)
-			break
+		if len(val) != 4 {
+			return fmt.Errorf("invalid enum value length %d", len(val))
 		}
+		v.Data = binary.LittleEndian.Uint32(val)
 
 	case Timestamp:
-		{
-			var n uint32
-			if val == nil {
-				n = 0
-			} else {
-				n = binary.BigEndian.Uint32(val)
-			}
-			v.Data = types.Timestamp{Value: n}
-			break
+		var n uint32
+		if len(val) == 4 {
+			n = binary.LittleEndian.Uint32(val)
+		} else if len(val) != 0 {
+			return fmt.Errorf("invalid timestamp value length %d", len(val))
 		}
+		v.Data = types.Timestamp{Value: n}
 
 	case Bool:
-		{
-			v.Data = val[0] != 0
-			break
+		if len(val) != 1 {
+			return fmt.Errorf("invalid bool value length %d", len(val))
 		}
+		v.Data = val[0] != 0
 
 	default:
-		panic("Unsupported value type")
+		return fmt.Errorf("Unsupported value type %s", vmtype)
 	}
 
-	return v
+	v.Type = vmtype
+	return nil
 }
 
 func (v *VMObject) Copy(other *VMObject) {
@@ -195,24 +209,133 @@ func (v *VMObject) Copy(other *VMObject) {
 	v.Type = other.Type
 
 	if other.Type == Struct {
-		panic("Struct type copying is unsupported")
+		v.Data = other.Data.(*VMStruct).Clone()
 	} else {
 		v.Data = other.Data
 	}
 }
 
-// Serialize implements ther Serializable interface
-func (v *VMObject) Serialize(writer *io.BinWriter) {
-	if v.Type == None {
-		return
+// Equals compares type and value of two objects. Structs are compared field by field,
+// other objects are compared by reference.
+func (v *VMObject) Equals(other *VMObject) bool {
+	if other == nil {
+		return v.Type == None
 	}
 
-	writer.WriteB(byte(v.Type))
+	if v.Type != other.Type {
+		return false
+	}
+
+	switch v.Type {
+	case None:
+		return true
+	case Number:
+		a := v.Data.(big.Int)
+		b := other.Data.(big.Int)
+		return a.Cmp(&b) == 0
+	case Bytes:
+		return bytes.Equal(v.Data.([]byte), other.Data.([]byte))
+	case Struct:
+		a := v.Data.(*VMStruct)
+		b := other.Data.(*VMStruct)
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := range a.Fields {
+			value, ok := b.Get(&a.Fields[i].Key)
+			if !ok || !value.Equals(&a.Fields[i].Value) {
+				return false
+			}
+		}
+		return true
+	case Object:
+		if a, ok := v.asAddress(); ok {
+			b, ok := other.asAddress()
+			return ok && bytes.Equal(a.Bytes(), b.Bytes())
+		}
+		return v.Data == other.Data
+	default:
+		return v.Data == other.Data
+	}
+}
+
+func (v *VMObject) asAddress() (cryptography.Address, bool) {
+	switch a := v.Data.(type) {
+	case cryptography.Address:
+		return a, true
+	case *cryptography.Address:
+		if a != nil {
+			return *a, true
+		}
+	}
+
+	return cryptography.Address{}, false
+}
+
+// AsAddress() returns value as an address, it can be stored as an address object,
+// as a string or as a byte array with or without length prefix
+func (v *VMObject) AsAddress() (cryptography.Address, error) {
 	switch v.Type {
+	case Object:
+		if a, ok := v.asAddress(); ok {
+			return a, nil
+		}
 	case String:
-		writer.WriteString(v.Data.(string))
+		a, err := cryptography.FromString(v.Data.(string))
+		if err != nil {
+			return cryptography.Address{}, fmt.Errorf("cannot convert %s to address: %w", v.Type, err)
+		}
+		return a, nil
+	case Bytes:
+		b := v.Data.([]byte)
+		if len(b) == cryptography.Length+1 && b[0] == cryptography.Length {
+			b = b[1:]
+		}
+		if len(b) == cryptography.Length {
+			return cryptography.NewAddress(bytes.Clone(b)), nil
+		}
+		return cryptography.Address{}, fmt.Errorf("cannot convert %s to address: invalid address length %d", v.Type, len(b))
+	}
+
+	return cryptography.Address{}, fmt.Errorf("cannot convert %s to address", v.Type)
+}
+
+// Serialize implements ther Serializable interface
+func (v *VMObject) Serialize(writer *io.BinWriter) {
+	writer.WriteB(byte(v.Type))
+
+	switch v.Type {
+	case None:
+	case Bool:
+		writer.WriteBool(v.Data.(bool))
 	case Bytes:
 		writer.WriteVarBytes(v.Data.([]byte))
+	case Enum:
+		writer.WriteU32LE(v.Data.(uint32))
+	case Number:
+		n := v.Data.(big.Int)
+		writer.WriteBigInteger(&n)
+	case Object:
+		a, ok := v.asAddress()
+		if !ok {
+			writer.Err = fmt.Errorf("objects of type %T cannot be serialized", v.Data)
+			return
+		}
+		writer.WriteVarBytes(io.Serialize(&a))
+	case String:
+		writer.WriteString(v.Data.(string))
+	case Struct:
+		children := v.Data.(*VMStruct)
+		writer.WriteVarUint(uint64(children.Len()))
+		for i := range children.Fields {
+			children.Fields[i].Key.Serialize(writer)
+			children.Fields[i].Value.Serialize(writer)
+		}
+	case Timestamp:
+		t := v.Data.(types.Timestamp)
+		writer.WriteTimestamp(&t)
+	default:
+		writer.Err = fmt.Errorf("cannot serialize object of type %s", v.Type)
 	}
 }
 
@@ -255,26 +378,215 @@ func (v *VMObject) Deserialize(reader *io.BinReader) {
 		v.Data = reader.ReadString()
 	case Struct:
 		childCount := reader.ReadVarUint()
-		children := make(map[VMObject]VMObject)
+		children := NewVMStruct()
 		for {
-			if childCount == 0 {
+			if childCount == 0 || reader.Err != nil {
 				break
 			}
 
 			key := &VMObject{}
 			key.Deserialize(reader)
+			if reader.Err != nil {
+				break
+			}
 
-			ValidateStructKey(key)
+			if err := validateKey(key); err != nil {
+				reader.Err = err
+				break
+			}
 
 			val := &VMObject{}
 			val.Deserialize(reader)
 
-			children[*key] = *val
+			children.Set(*key, *val)
 			childCount--
 		}
 
 		v.Data = children
 	case Timestamp:
 		v.Data = *reader.ReadTimestamp()
+	case None:
+		v.Data = nil
+	default:
+		reader.Err = fmt.Errorf("cannot deserialize object of type %s", v.Type)
+	}
+}
+
+// NewNumber returns a Number object
+func NewNumber(n *big.Int) *VMObject {
+	return &VMObject{Type: Number, Data: *new(big.Int).Set(n)}
+}
+
+// NewString returns a String object
+func NewString(s string) *VMObject {
+	return &VMObject{Type: String, Data: s}
+}
+
+// NewBool returns a Bool object
+func NewBool(b bool) *VMObject {
+	return &VMObject{Type: Bool, Data: b}
+}
+
+// NewBytes returns a Bytes object
+func NewBytes(b []byte) *VMObject {
+	return &VMObject{Type: Bytes, Data: b}
+}
+
+// NewTimestamp returns a Timestamp object
+func NewTimestamp(t types.Timestamp) *VMObject {
+	return &VMObject{Type: Timestamp, Data: t}
+}
+
+// NewEnum returns an Enum object
+func NewEnum(value uint32) *VMObject {
+	return &VMObject{Type: Enum, Data: value}
+}
+
+// NewAddress returns an Object holding an address
+func NewAddress(address cryptography.Address) *VMObject {
+	return &VMObject{Type: Object, Data: &address}
+}
+
+// IsEmpty returns true for objects of None type
+func (v *VMObject) IsEmpty() bool {
+	return v.Type == None
+}
+
+// toNumber converts object to number, same way Phantasma node does
+func (v *VMObject) toNumber() (*big.Int, error) {
+	switch v.Type {
+	case None:
+		return big.NewInt(0), nil
+
+	case Number:
+		n := v.Data.(big.Int)
+		return new(big.Int).Set(&n), nil
+
+	case String:
+		n, ok := big.NewInt(0).SetString(v.Data.(string), 10)
+		if !ok {
+			return nil, fmt.Errorf("cannot convert string %q to number", v.Data.(string))
+		}
+		return n, nil
+
+	case Bytes:
+		b := v.Data.([]byte)
+		if len(b) == 0 {
+			return big.NewInt(0), nil
+		}
+		return util.BigIntFromCsharpOrPhantasmaByteArray(b), nil
+
+	case Enum:
+		return big.NewInt(int64(v.Data.(uint32))), nil
+
+	case Bool:
+		if v.Data.(bool) {
+			return big.NewInt(1), nil
+		}
+		return big.NewInt(0), nil
+
+	case Timestamp:
+		return big.NewInt(int64(v.Data.(types.Timestamp).Value)), nil
+	}
+
+	return nil, fmt.Errorf("cannot convert %s to number", v.Type)
+}
+
+// toString converts object to string, same way Phantasma node does
+func (v *VMObject) toString() (string, error) {
+	switch v.Type {
+	case None:
+		return "", nil
+
+	case String:
+		return v.Data.(string), nil
+
+	case Bytes:
+		return string(v.Data.([]byte)), nil
+
+	case Number, Enum, Timestamp:
+		n, err := v.toNumber()
+		if err != nil {
+			return "", err
+		}
+		return n.String(), nil
+
+	case Bool:
+		return strconv.FormatBool(v.Data.(bool)), nil
+
+	case Object:
+		if a, ok := v.asAddress(); ok {
+			return a.String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("cannot convert %s to string", v.Type)
+}
+
+// toBool converts object to bool, same way Phantasma node does
+func (v *VMObject) toBool() (bool, error) {
+	switch v.Type {
+	case None:
+		return false, nil
+
+	case Bool:
+		return v.Data.(bool), nil
+
+	case String:
+		return strings.EqualFold(v.Data.(string), "true"), nil
+
+	case Bytes:
+		b := v.Data.([]byte)
+		return len(b) > 0 && b[0] != 0, nil
+
+	case Number, Enum:
+		n, err := v.toNumber()
+		if err != nil {
+			return false, err
+		}
+		return n.Sign() != 0, nil
+	}
+
+	return false, fmt.Errorf("cannot convert %s to bool", v.Type)
+}
+
+// toBytes converts object to byte array, same way Phantasma node does
+func (v *VMObject) toBytes() ([]byte, error) {
+	switch v.Type {
+	case None:
+		return []byte{}, nil
+
+	case Bytes:
+		return v.Data.([]byte), nil
+
+	case String:
+		return []byte(v.Data.(string)), nil
+
+	case Number:
+		n := v.Data.(big.Int)
+		return util.BigIntToCsharpByteArray(&n), nil
+
+	case Bool:
+		if v.Data.(bool) {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+
+	case Enum:
+		b := make([]byte, 4)
+		binary.LittleEndian.PutUint32(b, v.Data.(uint32))
+		return b, nil
+
+	case Timestamp:
+		b := make([]byte, 4)
+		binary.LittleEndian.PutUint32(b, v.Data.(types.Timestamp).Value)
+		return b, nil
+
+	case Object:
+		if a, ok := v.asAddress(); ok {
+			return a.Bytes(), nil
+		}
 	}
+
+	return nil, fmt.Errorf("cannot convert %s to bytes", v.Type)
 }
@@ -0,0 +1,152 @@
+package vm
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/types"
+)
+
+// jsonObject is a JSON representation of VMObject. Value depends on the type:
+// Number is a decimal string, Bytes are hex encoded, Timestamp is a number of seconds,
+// Object is an address text and Struct is an array of key-value pairs.
+type jsonObject struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+type jsonField struct {
+	Key   VMObject `json:"key"`
+	Value VMObject `json:"value"`
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (v VMObject) MarshalJSON() ([]byte, error) {
+	var value interface{}
+
+	switch v.Type {
+	case None:
+		return json.Marshal(jsonObject{Type: v.Type.String()})
+	case Number:
+		n := v.Data.(big.Int)
+		value = n.String()
+	case String:
+		value = v.Data.(string)
+	case Bool:
+		value = v.Data.(bool)
+	case Bytes:
+		value = hex.EncodeToString(v.Data.([]byte))
+	case Timestamp:
+		value = v.Data.(types.Timestamp).Value
+	case Enum:
+		value = v.Data.(uint32)
+	case Object:
+		a, ok := v.asAddress()
+		if !ok {
+			return nil, fmt.Errorf("objects of type %T cannot be encoded", v.Data)
+		}
+		value = a
+	case Struct:
+		fields := v.Data.(*VMStruct).Fields
+		pairs := make([]jsonField, len(fields))
+		for i := range fields {
+			pairs[i] = jsonField{Key: fields[i].Key, Value: fields[i].Value}
+		}
+		value = pairs
+	default:
+		return nil, fmt.Errorf("cannot encode object of type %s", v.Type)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(jsonObject{Type: v.Type.String(), Value: data})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (v *VMObject) UnmarshalJSON(data []byte) error {
+	var obj jsonObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	t, err := ParseVMType(obj.Type)
+	if err != nil {
+		return err
+	}
+
+	if t == None {
+		*v = VMObject{}
+		return nil
+	}
+
+	if len(obj.Value) == 0 {
+		return fmt.Errorf("value of %s object is missing", t)
+	}
+
+	result := VMObject{Type: t}
+
+	switch t {
+	case Number:
+		var s string
+		if err = json.Unmarshal(obj.Value, &s); err == nil {
+			n, ok := new(big.Int).SetString(s, 10)
+			if !ok {
+				return fmt.Errorf("invalid number %q", s)
+			}
+			result.Data = *n
+		}
+	case String:
+		var s string
+		err = json.Unmarshal(obj.Value, &s)
+		result.Data = s
+	case Bool:
+		var b bool
+		err = json.Unmarshal(obj.Value, &b)
+		result.Data = b
+	case Bytes:
+		var s string
+		if err = json.Unmarshal(obj.Value, &s); err == nil {
+			var b []byte
+			b, err = hex.DecodeString(s)
+			result.Data = b
+		}
+	case Timestamp:
+		var n uint32
+		err = json.Unmarshal(obj.Value, &n)
+		result.Data = types.Timestamp{Value: n}
+	case Enum:
+		var n uint32
+		err = json.Unmarshal(obj.Value, &n)
+		result.Data = n
+	case Object:
+		var a cryptography.Address
+		err = json.Unmarshal(obj.Value, &a)
+		result.Data = &a
+	case Struct:
+		var pairs []jsonField
+		if err = json.Unmarshal(obj.Value, &pairs); err == nil {
+			s := NewVMStruct()
+			for i := range pairs {
+				switch pairs[i].Key.Type {
+				case None, Struct, Object:
+					return fmt.Errorf("%s can't be used as a struct key", pairs[i].Key.Type)
+				}
+				s.Set(pairs[i].Key, pairs[i].Value)
+			}
+			result.Data = s
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid %s value: %w", t, err)
+	}
+
+	*v = result
+	return nil
+}
@@ -0,0 +1,213 @@
+package vm_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/types"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAddress = "P2KM9FjYrDXnPPAynLXAHdQ8wYz8de9VbDeybrLepnw6C5x"
+
+func TestVMObjectSerialization(t *testing.T) {
+	address, err := cryptography.FromString(testAddress)
+	require.Nil(t, err)
+
+	fields := vm.NewVMStruct()
+	fields.Set(*vm.NewString("name"), *vm.NewString("SOUL"))
+	fields.Set(*vm.NewString("decimals"), *vm.NewNumber(big.NewInt(8)))
+
+	nested := vm.NewVMStruct()
+	nested.Set(*vm.NewNumber(big.NewInt(0)), *vm.NewAddress(address))
+	nested.Set(*vm.NewNumber(big.NewInt(1)), vm.VMObject{Type: vm.Struct, Data: fields})
+	nested.Set(*vm.NewNumber(big.NewInt(2)), vm.VMObject{})
+
+	// expected values are written by hand in the format of invokeRawScript results: type byte followed by
+	// data, numbers are varbytes of Phantasma signed little-endian bytes (see util reference data)
+	const (
+		addressHex = "01010203040506070809101112131415161718192021222324252627282930313233"
+		fieldsHex  = "0102" +
+			"04046e616d65" + "0404534f554c" + // "name": "SOUL"
+			"0408646563696d616c73" + "03020800" // "decimals": 8
+	)
+
+	testCases := []struct {
+		name   string
+		object *vm.VMObject
+		hex    string
+	}{
+		{"none", &vm.VMObject{}, "00"},
+		{"number", vm.NewNumber(big.NewInt(100000000)), "030500e1f50500"},
+		{"negative number", vm.NewNumber(big.NewInt(-1)), "0303ffffff"},
+		{"zero", vm.NewNumber(big.NewInt(0)), "030100"},
+		{"string", vm.NewString("SOUL"), "0404534f554c"},
+		{"bytes", vm.NewBytes([]byte{1, 2, 3}), "0203010203"},
+		{"bool", vm.NewBool(true), "0601"},
+		{"timestamp", vm.NewTimestamp(types.Timestamp{Value: 1623519055}), "054fefc460"},
+		{"enum", vm.NewEnum(2), "0702000000"},
+		// object data is varbytes of serialized address, which is varbytes itself
+		{"address", vm.NewAddress(address), "082322" + addressHex},
+		{"struct", &vm.VMObject{Type: vm.Struct, Data: fields}, fieldsHex},
+		{"nested struct", &vm.VMObject{Type: vm.Struct, Data: nested}, "0103" +
+			"030100" + "082322" + addressHex + // 0: address
+			"03020100" + fieldsHex + // 1: struct
+			"03020200" + "00"}, // 2: none
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := io.Serialize(tc.object)
+			require.NotNil(t, data)
+
+			assert.Equal(t, tc.hex, hex.EncodeToString(data))
+
+			decoded := io.Deserialize[*vm.VMObject](data)
+			assert.True(t, tc.object.Equals(decoded), "%s != %s", tc.object, decoded)
+			assert.Equal(t, data, io.Serialize(decoded))
+		})
+	}
+}
+
+func TestVMObjectSerializationErrors(t *testing.T) {
+	assert.Nil(t, io.Serialize(&vm.VMObject{Type: vm.Object, Data: 42}))
+	assert.Nil(t, io.Serialize(&vm.VMObject{Type: vm.VMType(42)}))
+
+	br := io.NewBinReaderFromBuf([]byte{42})
+	(&vm.VMObject{}).Deserialize(br)
+	assert.NotNil(t, br.Err)
+
+	// struct of 2 fields with data of the first one only
+	truncated := []byte{byte(vm.Struct), 2, byte(vm.String), 1, 'a', byte(vm.Bool), 1}
+	assert.NotPanics(t, func() {
+		br = io.NewBinReaderFromBuf(truncated)
+		(&vm.VMObject{}).Deserialize(br)
+	})
+	assert.NotNil(t, br.Err)
+
+	br = io.NewBinReaderFromBuf([]byte{byte(vm.Struct), 1, byte(vm.None), byte(vm.Bool), 1})
+	(&vm.VMObject{}).Deserialize(br)
+	assert.ErrorContains(t, br.Err, "cannot use value of type None as key")
+}
+
+func TestVMObjectAccessors(t *testing.T) {
+	n, err := vm.NewBytes([]byte{0x00, 0x01}).AsNumberE()
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(256), n)
+
+	n, err = vm.NewBytes([]byte{0xff}).AsNumberE()
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(-1), n)
+
+	_, err = vm.NewString("12a").AsNumberE()
+	assert.NotNil(t, err)
+
+	fields := vm.NewVMStruct()
+	fields.Set(*vm.NewString("name"), *vm.NewString("SOUL"))
+	structObj := &vm.VMObject{Type: vm.Struct, Data: fields}
+
+	_, err = structObj.AsStringE()
+	assert.NotNil(t, err)
+	assert.Panics(t, func() { structObj.AsString() })
+
+	address, err := cryptography.FromString(testAddress)
+	require.Nil(t, err)
+	s, err := vm.NewAddress(address).AsStringE()
+	require.Nil(t, err)
+	assert.Equal(t, testAddress, s)
+
+	b, err := vm.NewString("true").AsBool()
+	require.Nil(t, err)
+	assert.True(t, b)
+
+	_, err = structObj.AsBool()
+	assert.NotNil(t, err)
+
+	bytes, err := vm.NewEnum(1).AsBytes()
+	require.Nil(t, err)
+	assert.Equal(t, []byte{1, 0, 0, 0}, bytes)
+
+	a, err := vm.NewString(testAddress).AsAddress()
+	require.Nil(t, err)
+	assert.Equal(t, testAddress, a.String())
+
+	a, err = vm.NewBytes(address.Bytes()).AsAddress()
+	require.Nil(t, err)
+	assert.Equal(t, testAddress, a.String())
+
+	_, err = vm.NewBytes([]byte{1, 2, 3}).AsAddress()
+	assert.NotNil(t, err)
+	_, err = vm.NewString("invalid").AsAddress()
+	assert.NotNil(t, err)
+
+	ts, err := vm.NewNumber(big.NewInt(1623519055)).AsTimestamp()
+	require.Nil(t, err)
+	assert.Equal(t, uint32(1623519055), ts.Value)
+
+	_, err = vm.NewNumber(big.NewInt(-1)).AsTimestamp()
+	assert.NotNil(t, err)
+
+	m, err := structObj.AsMap()
+	require.Nil(t, err)
+	name := m["name"]
+	assert.Equal(t, "SOUL", name.AsString())
+
+	_, err = structObj.AsArray()
+	assert.NotNil(t, err)
+
+	items := vm.NewVMStruct()
+	items.Set(*vm.NewNumber(big.NewInt(0)), *vm.NewString("a"))
+	items.Set(*vm.NewNumber(big.NewInt(1)), *vm.NewString("b"))
+	array, err := (&vm.VMObject{Type: vm.Struct, Data: items}).AsArray()
+	require.Nil(t, err)
+	require.Len(t, array, 2)
+	assert.Equal(t, "b", array[1].AsString())
+
+	_, err = vm.NewString("a").AsMap()
+	assert.NotNil(t, err)
+
+	var obj vm.VMObject
+	assert.NotNil(t, obj.SetValueE([]byte{1, 2}, vm.Bool))
+	assert.NotNil(t, obj.SetValueE([]byte{1}, vm.Struct))
+	assert.Nil(t, obj.SetValueE([]byte{1}, vm.Bool))
+	assert.Equal(t, vm.Bool, obj.Type)
+}
+
+func TestVMObjectJSON(t *testing.T) {
+	address, err := cryptography.FromString(testAddress)
+	require.Nil(t, err)
+
+	items := vm.NewVMStruct()
+	items.Set(*vm.NewNumber(big.NewInt(0)), *vm.NewAddress(address))
+	items.Set(*vm.NewString("time"), *vm.NewTimestamp(types.Timestamp{Value: 1623519055}))
+	items.Set(*vm.NewString("data"), *vm.NewBytes([]byte{0xca, 0xfe}))
+	items.Set(*vm.NewString("flags"), *vm.NewEnum(3))
+	items.Set(*vm.NewString("burnable"), *vm.NewBool(true))
+	items.Set(*vm.NewString("empty"), vm.VMObject{})
+	obj := vm.VMObject{Type: vm.Struct, Data: items}
+
+	data, err := json.Marshal(obj)
+	require.Nil(t, err)
+
+	var decoded vm.VMObject
+	require.Nil(t, json.Unmarshal(data, &decoded))
+	assert.True(t, obj.Equals(&decoded))
+
+	data, err = json.Marshal(vm.NewNumber(big.NewInt(-100000000)))
+	require.Nil(t, err)
+	assert.Equal(t, `{"type":"Number","value":"-100000000"}`, string(data))
+
+	data, err = json.Marshal(vm.NewAddress(address))
+	require.Nil(t, err)
+	assert.Equal(t, `{"type":"Object","value":"`+testAddress+`"}`, string(data))
+
+	assert.NotNil(t, json.Unmarshal([]byte(`{"type":"Number","value":"1.5"}`), &decoded))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"type":"Unknown","value":1}`), &decoded))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"type":"String"}`), &decoded))
+}
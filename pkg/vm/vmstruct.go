@@ -0,0 +1,77 @@
+package vm
+
+// StructField is a single key-value entry of a struct
+type StructField struct {
+	Key   VMObject
+	Value VMObject
+}
+
+// VMStruct holds data of a Struct vm object. Fields are kept in insertion order,
+// same as Phantasma node does, which makes serialization deterministic.
+// VMObject can't be used as a Go map key since Number data is not comparable,
+// so lookups are done by comparing keys with VMObject.Equals().
+type VMStruct struct {
+	Fields []StructField
+}
+
+// NewVMStruct returns an empty struct
+func NewVMStruct() *VMStruct {
+	return &VMStruct{Fields: []StructField{}}
+}
+
+// Len returns number of struct fields
+func (s *VMStruct) Len() int {
+	return len(s.Fields)
+}
+
+func (s *VMStruct) indexOf(key *VMObject) int {
+	for i := range s.Fields {
+		if s.Fields[i].Key.Equals(key) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Get returns value stored under the given key
+func (s *VMStruct) Get(key *VMObject) (VMObject, bool) {
+	i := s.indexOf(key)
+	if i < 0 {
+		return VMObject{}, false
+	}
+
+	return s.Fields[i].Value, true
+}
+
+// Set stores value under the given key, replacing existing value if key is already present
+func (s *VMStruct) Set(key, value VMObject) {
+	i := s.indexOf(&key)
+	if i < 0 {
+		s.Fields = append(s.Fields, StructField{Key: key, Value: value})
+	} else {
+		s.Fields[i].Value = value
+	}
+}
+
+// Remove deletes field with the given key, returns false if key was not found
+func (s *VMStruct) Remove(key *VMObject) bool {
+	i := s.indexOf(key)
+	if i < 0 {
+		return false
+	}
+
+	s.Fields = append(s.Fields[:i], s.Fields[i+1:]...)
+	return true
+}
+
+// Clone returns a deep copy of the struct
+func (s *VMStruct) Clone() *VMStruct {
+	clone := &VMStruct{Fields: make([]StructField, len(s.Fields))}
+	for i, f := range s.Fields {
+		clone.Fields[i].Key.Copy(&f.Key)
+		clone.Fields[i].Value.Copy(&f.Value)
+	}
+
+	return clone
+}
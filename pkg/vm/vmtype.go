@@ -1,6 +1,9 @@
 package vm
 
-//go:generate stringer -type=Opcode -linecomment
+import (
+	"fmt"
+	"strings"
+)
 
 // VMType identifies the type of a vm object
 type VMType byte
@@ -30,13 +33,29 @@ var VMTypeLookup = map[VMType]string{
 	Object:    `Object`,
 }
 
+func (t VMType) String() string {
+	if s, ok := VMTypeLookup[t]; ok {
+		return s
+	}
+
+	return "Unknown"
+}
+
+// FromString returns vm type by its name, None is returned for unknown names.
+//
+// Deprecated: use ParseVMType, which reports unknown names.
 func (t VMType) FromString(vmType string) VMType {
-	for k1, s := range VMTypeLookup {
-		if s == vmType {
+	parsed, _ := ParseVMType(vmType)
+	return parsed
+}
 
-			return k1
+// ParseVMType returns vm type by its name, names are case-insensitive
+func ParseVMType(name string) (VMType, error) {
+	for t, s := range VMTypeLookup {
+		if strings.EqualFold(s, name) {
+			return t, nil
 		}
 	}
 
-	return None
+	return None, fmt.Errorf("unknown vm type %q", name)
 }
@@ -0,0 +1,48 @@
+package voting
+
+import (
+	"fmt"
+	"math/big"
+
+	crypto "github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/consensus"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+)
+
+// SingleVote appends vote for the choice with index of the consensus poll to the script
+func SingleVote(sb scriptbuilder.ScriptBuilder, from crypto.Address, subject string, index *big.Int) scriptbuilder.ScriptBuilder {
+	return sb.CallContract("consensus", "SingleVote", from, subject, index)
+}
+
+// MultiVote appends votes split between several choices of the consensus poll to the script, votes should be
+// checked with ConsensusPoll.ValidateVotes first. Nothing is appended if votes can't be encoded.
+func MultiVote(sb scriptbuilder.ScriptBuilder, from crypto.Address, subject string, votes []consensus.PollVote) (scriptbuilder.ScriptBuilder, error) {
+	choices, err := EncodeVotes(votes)
+	if err != nil {
+		return sb, err
+	}
+
+	return sb.CallContract("consensus", "MultiVote", from, subject, choices), nil
+}
+
+// RemoveVotes appends removal of votes of from in the consensus poll to the script
+func RemoveVotes(sb scriptbuilder.ScriptBuilder, from crypto.Address, subject string) scriptbuilder.ScriptBuilder {
+	return sb.CallContract("consensus", "RemoveVotes", from, subject)
+}
+
+// EncodeVotes returns votes as an array of PollChoice structs expected by MultiVote method of consensus contract
+func EncodeVotes(votes []consensus.PollVote) (*vm.VMObject, error) {
+	for _, v := range votes {
+		if v.Index == nil || v.Percentage == nil {
+			return nil, fmt.Errorf("%w: index and percentage should be set", consensus.ErrInvalidVote)
+		}
+	}
+
+	choices, err := vm.Marshal(votes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode votes: %w", err)
+	}
+
+	return choices, nil
+}
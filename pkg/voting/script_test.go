@@ -0,0 +1,63 @@
+package voting_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/consensus"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+	"github.com/phantasma-io/phantasma-go/pkg/voting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVoteScripts(t *testing.T) {
+	from := cryptography.NewPhantasmaKeys(make([]byte, 32)).Address()
+
+	script := voting.SingleVote(scriptbuilder.BeginScript(), from, "elections", big.NewInt(2)).EndScript()
+	expected := scriptbuilder.BeginScript().CallContract("consensus", "SingleVote", from, "elections", big.NewInt(2)).EndScript()
+	assert.Equal(t, expected, script)
+
+	votes := consensus.RankedVotes(2, 0, 1)
+	sb, err := voting.MultiVote(scriptbuilder.BeginScript(), from, "elections", votes)
+	require.Nil(t, err)
+	choices, err := vm.Marshal(votes)
+	require.Nil(t, err)
+	expected = scriptbuilder.BeginScript().CallContract("consensus", "MultiVote", from, "elections", choices).EndScript()
+	assert.Equal(t, expected, sb.EndScript())
+
+	script = voting.RemoveVotes(scriptbuilder.BeginScript(), from, "elections").EndScript()
+	expected = scriptbuilder.BeginScript().CallContract("consensus", "RemoveVotes", from, "elections").EndScript()
+	assert.Equal(t, expected, script)
+}
+
+func TestEncodeVotes(t *testing.T) {
+	votes := consensus.RankedVotes(2, 0, 1)
+	obj, err := voting.EncodeVotes(votes)
+	require.Nil(t, err)
+
+	// choices are loaded into the VM as an array of structs
+	script := scriptbuilder.BeginScript().
+		EmitLoadObject(0, obj).
+		EmitPush(0).
+		EmitS(vm.RET).
+		EndScript()
+	machine := vm.NewVirtualMachine(script)
+	state, err := machine.Execute()
+	require.Nil(t, err)
+	require.Equal(t, vm.Halt, state)
+
+	result, err := machine.Pop()
+	require.Nil(t, err)
+	var decoded []consensus.PollVote
+	require.Nil(t, vm.Unmarshal(result, &decoded))
+	assert.Equal(t, votes, decoded)
+
+	sb := scriptbuilder.BeginScript()
+	_, err = voting.MultiVote(sb, cryptography.NullAddress(), "elections", []consensus.PollVote{{Index: big.NewInt(0)}})
+	assert.True(t, errors.Is(err, consensus.ErrInvalidVote))
+	assert.Equal(t, scriptbuilder.BeginScript().EndScript(), sb.EndScript())
+}
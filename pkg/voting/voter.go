@@ -0,0 +1,152 @@
+// Package voting reads polls of the consensus contract and builds signed vote transactions
+// after checking that votes can be accepted by the poll.
+package voting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/phantasma-io/phantasma-go/pkg/blockchain"
+	crypto "github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/consensus"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/types"
+	"github.com/phantasma-io/phantasma-go/pkg/rpc"
+	"github.com/phantasma-io/phantasma-go/pkg/txbuilder"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+	scriptbuilder "github.com/phantasma-io/phantasma-go/pkg/vm/script_builder"
+)
+
+var (
+	ErrPollInactive = errors.New("poll is not active")
+)
+
+// Client is a subset of RPC methods used by Voter, it's implemented by rpc.PhantasmaRPC
+type Client interface {
+	Call(ctx context.Context, chain, contract, method string, args ...interface{}) (*vm.VMObject, error)
+	MultiCall(ctx context.Context, chain string, calls ...rpc.ContractCall) ([]*vm.VMObject, error)
+	SendRawTransaction(txData string) (string, error)
+}
+
+// Voter reads consensus polls and builds vote transactions of a single address. Builder's Now is used
+// to check if polls are active as well.
+type Voter struct {
+	Client Client
+	txbuilder.Builder
+}
+
+// NewVoter returns voter signing transactions with keys on the main chain of the nexus
+func NewVoter(client Client, nexus string, keys crypto.PhantasmaKeys) *Voter {
+	return &Voter{Client: client, Builder: txbuilder.New(nexus, keys)}
+}
+
+// Poll returns poll with its choices and votes
+func (v *Voter) Poll(ctx context.Context, subject string) (consensus.ConsensusPoll, error) {
+	var poll consensus.ConsensusPoll
+	obj, err := v.Client.Call(ctx, v.Chain, "consensus", "GetConsensusPoll", subject)
+	if err != nil {
+		return poll, err
+	}
+
+	if err := vm.Unmarshal(obj, &poll); err != nil {
+		return poll, fmt.Errorf("invalid poll %s: %w", subject, err)
+	}
+
+	return poll, nil
+}
+
+// Polls returns several polls with a single call, in the order of subjects
+func (v *Voter) Polls(ctx context.Context, subjects ...string) ([]consensus.ConsensusPoll, error) {
+	if len(subjects) == 0 {
+		return []consensus.ConsensusPoll{}, nil
+	}
+
+	polls := make([]consensus.ConsensusPoll, len(subjects))
+	calls := make([]rpc.ContractCall, len(subjects))
+	for i, subject := range subjects {
+		calls[i] = rpc.NewContractCall("consensus", "GetConsensusPoll", subject).Into(&polls[i])
+	}
+
+	if _, err := v.Client.MultiCall(ctx, v.Chain, calls...); err != nil {
+		return nil, err
+	}
+
+	return polls, nil
+}
+
+// ActivePolls returns polls of subjects accepting votes now. Subjects of new polls can be
+// collected from PollCreated events with consensus.PollEventsFromBlock.
+func (v *Voter) ActivePolls(ctx context.Context, subjects ...string) ([]consensus.ConsensusPoll, error) {
+	polls, err := v.Polls(ctx, subjects...)
+	if err != nil {
+		return nil, err
+	}
+
+	now := v.now()
+	active := []consensus.ConsensusPoll{}
+	for _, p := range polls {
+		if p.IsActive(now) {
+			active = append(active, p)
+		}
+	}
+
+	return active, nil
+}
+
+// Vote returns signed transaction casting votes in the poll. ErrPollInactive is returned if poll
+// doesn't accept votes now and consensus.ErrInvalidVote if votes are not valid for the poll.
+// consensus.SingleVote and consensus.RankedVotes build votes for one or several ranked choices.
+func (v *Voter) Vote(ctx context.Context, subject string, votes []consensus.PollVote) (blockchain.Transaction, error) {
+	poll, err := v.Poll(ctx, subject)
+	if err != nil {
+		return blockchain.Transaction{}, err
+	}
+
+	if !poll.IsActive(v.now()) {
+		return blockchain.Transaction{}, fmt.Errorf("%w: %s is %s", ErrPollInactive, subject, poll.State)
+	}
+
+	if err := poll.ValidateVotes(votes); err != nil {
+		return blockchain.Transaction{}, err
+	}
+
+	address := v.Keys.Address()
+	var encodeErr error
+	tx := v.Transaction(func(sb scriptbuilder.ScriptBuilder) scriptbuilder.ScriptBuilder {
+		if len(votes) == 1 {
+			return SingleVote(sb, address, subject, votes[0].Index)
+		}
+		sb, encodeErr = MultiVote(sb, address, subject, votes)
+		return sb
+	})
+	if encodeErr != nil {
+		return blockchain.Transaction{}, encodeErr
+	}
+
+	return tx, nil
+}
+
+// RemoveVotes returns signed transaction removing votes cast in the poll
+func (v *Voter) RemoveVotes(ctx context.Context, subject string) (blockchain.Transaction, error) {
+	poll, err := v.Poll(ctx, subject)
+	if err != nil {
+		return blockchain.Transaction{}, err
+	}
+
+	if !poll.IsActive(v.now()) {
+		return blockchain.Transaction{}, fmt.Errorf("%w: %s is %s", ErrPollInactive, subject, poll.State)
+	}
+
+	return v.Transaction(func(sb scriptbuilder.ScriptBuilder) scriptbuilder.ScriptBuilder {
+		return RemoveVotes(sb, v.Keys.Address(), subject)
+	}), nil
+}
+
+// Send broadcasts transaction and returns its hash
+func (v *Voter) Send(tx blockchain.Transaction) (string, error) {
+	return txbuilder.Send(v.Client, tx)
+}
+
+func (v *Voter) now() types.Timestamp {
+	return types.Timestamp{Value: uint32(v.Now().Unix())}
+}
@@ -0,0 +1,140 @@
+package voting_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/phantasma-io/phantasma-go/pkg/blockchain"
+	"github.com/phantasma-io/phantasma-go/pkg/cryptography"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/consensus"
+	"github.com/phantasma-io/phantasma-go/pkg/domain/types"
+	"github.com/phantasma-io/phantasma-go/pkg/io"
+	"github.com/phantasma-io/phantasma-go/pkg/rpc"
+	"github.com/phantasma-io/phantasma-go/pkg/vm"
+	"github.com/phantasma-io/phantasma-go/pkg/voting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	polls map[string]consensus.ConsensusPoll
+	sent  []string
+}
+
+func (c *fakeClient) Call(ctx context.Context, chain, contract, method string, args ...interface{}) (*vm.VMObject, error) {
+	if contract != "consensus" || method != "GetConsensusPoll" {
+		return nil, errors.New("unknown method")
+	}
+
+	poll, ok := c.polls[args[0].(string)]
+	if !ok {
+		return nil, errors.New("poll not found")
+	}
+
+	return vm.Marshal(poll)
+}
+
+func (c *fakeClient) MultiCall(ctx context.Context, chain string, calls ...rpc.ContractCall) ([]*vm.VMObject, error) {
+	results := make([]*vm.VMObject, len(calls))
+	for i, call := range calls {
+		obj, err := c.Call(ctx, chain, call.Contract, call.Method, call.Args...)
+		if err != nil {
+			return nil, err
+		}
+		if err := vm.Unmarshal(obj, call.Result); err != nil {
+			return nil, err
+		}
+		results[i] = obj
+	}
+
+	return results, nil
+}
+
+func (c *fakeClient) SendRawTransaction(txData string) (string, error) {
+	c.sent = append(c.sent, txData)
+	return "hash", nil
+}
+
+func newVoter() (*voting.Voter, *fakeClient) {
+	client := &fakeClient{polls: map[string]consensus.ConsensusPoll{
+		"elections": {
+			Subject: "elections",
+			Mode:    consensus.Ranking,
+			State:   consensus.Active,
+			Entries: []consensus.PollValue{
+				{Value: []byte("alice"), Votes: big.NewInt(0)},
+				{Value: []byte("bob"), Votes: big.NewInt(0)},
+			},
+			StartTime:      types.Timestamp{Value: 1000},
+			EndTime:        types.Timestamp{Value: 2000},
+			ChoicesPerUser: big.NewInt(2),
+		},
+		"closed": {
+			Subject:   "closed",
+			State:     consensus.Consensus,
+			Entries:   []consensus.PollValue{{Value: []byte("yes")}},
+			StartTime: types.Timestamp{Value: 0},
+			EndTime:   types.Timestamp{Value: 1500},
+		},
+	}}
+
+	v := voting.NewVoter(client, "simnet", cryptography.NewPhantasmaKeys(make([]byte, 32)))
+	v.Now = func() time.Time { return time.Unix(1200, 0) }
+	return v, client
+}
+
+func TestPolls(t *testing.T) {
+	v, _ := newVoter()
+	ctx := context.Background()
+
+	poll, err := v.Poll(ctx, "elections")
+	require.Nil(t, err)
+	assert.Equal(t, consensus.Ranking, poll.Mode)
+	assert.Equal(t, "bob", poll.Entries[1].Text())
+
+	polls, err := v.Polls(ctx, "closed", "elections")
+	require.Nil(t, err)
+	require.Len(t, polls, 2)
+	assert.Equal(t, "closed", polls[0].Subject)
+
+	active, err := v.ActivePolls(ctx, "closed", "elections")
+	require.Nil(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, "elections", active[0].Subject)
+
+	_, err = v.Poll(ctx, "unknown")
+	assert.NotNil(t, err)
+}
+
+func TestVote(t *testing.T) {
+	v, client := newVoter()
+	ctx := context.Background()
+
+	_, err := v.Vote(ctx, "closed", consensus.SingleVote(0))
+	assert.True(t, errors.Is(err, voting.ErrPollInactive))
+
+	_, err = v.Vote(ctx, "elections", consensus.SingleVote(5))
+	assert.True(t, errors.Is(err, consensus.ErrInvalidVote))
+
+	tx, err := v.Vote(ctx, "elections", consensus.RankedVotes(1, 0))
+	require.Nil(t, err)
+	assert.Equal(t, "simnet", tx.NexusName)
+	assert.Equal(t, uint32(1200+300), tx.Expiration)
+	assert.Len(t, tx.Signatures, 1)
+
+	_, err = v.Send(tx)
+	require.Nil(t, err)
+	require.Len(t, client.sent, 1)
+
+	b, err := hex.DecodeString(client.sent[0])
+	require.Nil(t, err)
+	sent := io.Deserialize[*blockchain.Transaction](b)
+	assert.Equal(t, tx.Script, sent.Script)
+
+	_, err = v.RemoveVotes(ctx, "elections")
+	assert.Nil(t, err)
+}